@@ -2,18 +2,17 @@ package invoiced
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
-	"io/ioutil"
-	"mime/multipart"
+	"log/slog"
 	"net/http"
-	"net/textproto"
-	"os"
-	"path/filepath"
+	"net/url"
+	"reflect"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -23,32 +22,81 @@ const (
 )
 
 type Api struct {
-	Sandbox bool
-	Key     string
-	client  *http.Client
-	baseUrl string
+	Sandbox         bool
+	Key             string
+	client          *http.Client
+	baseUrl         string
+	userAgentSuffix string
+	retryPolicy     *RetryPolicy
+	middleware      []Middleware
+	logger          *slog.Logger
+	redactedFields  map[string]bool
+	instrumentation Instrumentation
+	rateLimiter     *RateLimiter
+	pageConcurrency int
+	validation      bool
+
+	autoIdempotencyKeys bool
 }
 
-func New(key string, sandbox bool) *Api {
+func New(key string, sandbox bool, opts ...Option) *Api {
+	o := new(options)
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	url := productionUrl
 	if sandbox {
 		url = sandboxUrl
 	}
 
+	if o.baseUrl != "" {
+		url = strings.TrimRight(o.baseUrl, "/")
+	}
+
 	return &Api{
-		Sandbox: sandbox,
-		Key:     key,
-		client:  new(http.Client),
-		baseUrl: url,
+		Sandbox:             sandbox,
+		Key:                 key,
+		client:              o.buildHTTPClient(),
+		baseUrl:             url,
+		userAgentSuffix:     o.userAgentSuffix,
+		retryPolicy:         o.retryPolicy,
+		middleware:          o.middleware,
+		logger:              o.logger,
+		redactedFields:      newRedactedFields(o.redactedFields),
+		instrumentation:     o.instrumentation,
+		rateLimiter:         o.rateLimiter,
+		pageConcurrency:     o.pageConcurrency,
+		validation:          o.validation,
+		autoIdempotencyKeys: o.autoIdempotencyKeys,
 	}
 }
 
-func checkStatusForError(status int, r io.Reader) error {
-	if status < 400 {
+func (c *Api) userAgent() string {
+	if c.userAgentSuffix == "" {
+		return "Invoiced Go/" + version
+	}
+
+	return "Invoiced Go/" + version + " " + c.userAgentSuffix
+}
+
+const (
+	// maxErrorBodySize caps how much of an error response is read, in case
+	// a proxy answers with a large HTML page instead of a JSON error.
+	maxErrorBodySize = 64 << 10
+
+	// maxDrainSize caps how much of an unread body is discarded before
+	// closing it. Draining lets the connection be reused; beyond this size
+	// it is cheaper to open a new one.
+	maxDrainSize = 256 << 10
+)
+
+func checkStatusForError(resp *http.Response) error {
+	if resp.StatusCode < 400 {
 		return nil
 	}
 
-	body, err := ioutil.ReadAll(r)
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
 	if err != nil {
 		return err
 	}
@@ -61,21 +109,74 @@ func checkStatusForError(status int, r io.Reader) error {
 		apiError.Type = string(body)
 	}
 
-	return errors.New(apiError.Error())
+	apiError.StatusCode = resp.StatusCode
+	apiError.RequestId = resp.Header.Get("X-Request-Id")
+	apiError.Header = resp.Header
+
+	return apiError
 }
 
+// pushDataIntoStruct decodes a JSON response body as it is read instead of
+// buffering it first. List pages are decoded one element at a time, so only
+// a single element of the raw JSON is held in memory at once.
 func pushDataIntoStruct(endpointData interface{}, respBody io.Reader) error {
-	body, err := ioutil.ReadAll(respBody)
-	if err != nil {
-		return err
+	dec := json.NewDecoder(respBody)
+
+	list := reflect.ValueOf(endpointData)
+	if list.Kind() != reflect.Ptr || list.Elem().Kind() != reflect.Slice {
+		return decodeValue(dec, endpointData)
 	}
-	err = json.Unmarshal(body, endpointData)
 
-	if err != nil {
+	list = list.Elem()
+
+	token, err := dec.Token()
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	} else if err != nil {
 		return err
 	}
 
-	return nil
+	if token == nil {
+		list.Set(reflect.Zero(list.Type()))
+		return nil
+	}
+
+	if token != json.Delim('[') {
+		return &json.UnmarshalTypeError{Value: fmt.Sprint(token), Type: list.Type(), Offset: dec.InputOffset()}
+	}
+
+	list.SetLen(0)
+
+	for dec.More() {
+		list.Set(reflect.Append(list, reflect.Zero(list.Type().Elem())))
+
+		if err := decodeValue(dec, list.Index(list.Len()-1).Addr().Interface()); err != nil {
+			return err
+		}
+	}
+
+	_, err = dec.Token()
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+
+	return err
+}
+
+func decodeValue(dec *json.Decoder, v interface{}) error {
+	err := dec.Decode(v)
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+
+	return err
+}
+
+// closeBody discards what is left of a response body and closes it, so that
+// the connection can go back to the pool.
+func closeBody(resp *http.Response) {
+	_, _ = io.CopyN(io.Discard, resp.Body, maxDrainSize)
+	resp.Body.Close()
 }
 
 func parseLinkHeader(s string) map[string]string {
@@ -120,180 +221,102 @@ func parseLinkUrl(s string) string {
 	return trimmed
 }
 
-func AddQueryParameter(url string, name string, value string) string {
-	if strings.Contains(url, "?") {
-		url += "&"
+// AddQueryParameter appends a parameter to an endpoint, escaping its value.
+func AddQueryParameter(endpoint string, name string, value string) string {
+	if strings.Contains(endpoint, "?") {
+		endpoint += "&"
 	} else {
-		url += "?"
+		endpoint += "?"
 	}
 
-	return url + name + "=" + value
+	return endpoint + url.QueryEscape(name) + "=" + url.QueryEscape(value)
 }
 
-func (c *Api) get(endpoint string) (*http.Response, error) {
-	url := c.baseUrl + endpoint
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req.SetBasicAuth(c.Key, "")
-	req.Header.Set("User-Agent", "Invoiced Go/"+version)
-
-	resp, err := c.client.Do(req)
-
-	return resp, err
+func (c *Api) get(ctx context.Context, endpoint string) (*http.Response, error) {
+	return c.request(ctx, "GET", endpoint, nil, "")
 }
 
-func (c *Api) post(endpoint string, body io.Reader) (*http.Response, error) {
-	url := c.baseUrl + endpoint
-	req, err := http.NewRequest("POST", url, body)
-	if err != nil {
-		return nil, err
-	}
-
-	req.SetBasicAuth(c.Key, "")
-	req.Header.Set("User-Agent", "Invoiced Go/"+version)
-	req.Header.Set("Content-Type", requestType)
-
-	resp, err := c.client.Do(req)
-
-	return resp, err
+func (c *Api) post(ctx context.Context, endpoint string, payload []byte) (*http.Response, error) {
+	return c.request(ctx, "POST", endpoint, payload, requestType)
 }
 
-func (c *Api) postWithFormData(endpoint string, body io.Reader, formContentType string) (*http.Response, error) {
-	url := c.baseUrl + endpoint
-	req, err := http.NewRequest("POST", url, body)
-	if err != nil {
-		return nil, err
-	}
-
-	req.SetBasicAuth(c.Key, "")
-	req.Header.Set("User-Agent", "Invoiced Go/"+version)
-	req.Header.Set("Content-Type", formContentType)
-
-	resp, err := c.client.Do(req)
-
-	return resp, err
+func (c *Api) patch(ctx context.Context, endpoint string, payload []byte) (*http.Response, error) {
+	return c.request(ctx, "PATCH", endpoint, payload, requestType)
 }
 
-func (c *Api) patch(endpoint string, body io.Reader) (*http.Response, error) {
-	url := c.baseUrl + endpoint
-	req, err := http.NewRequest("PATCH", url, body)
-	if err != nil {
-		return nil, err
-	}
-
-	req.SetBasicAuth(c.Key, "")
-	req.Header.Set("User-Agent", "Invoiced Go/"+version)
-	req.Header.Set("Content-Type", requestType)
-
-	resp, err := c.client.Do(req)
-
-	return resp, err
+func (c *Api) deleteRequest(ctx context.Context, endpoint string) (*http.Response, error) {
+	return c.request(ctx, "DELETE", endpoint, nil, requestType)
 }
 
-func (c *Api) deleteRequest(endpoint string) (*http.Response, error) {
-	url := c.baseUrl + endpoint
-	req, err := http.NewRequest("DELETE", url, nil)
-	if err != nil {
-		return nil, err
+// request builds the HTTP request for an API call and sends it through the
+// middleware chain. Every call made by the Api goes through here.
+func (c *Api) request(ctx context.Context, method string, endpoint string, payload []byte, contentType string) (*http.Response, error) {
+	if payload == nil {
+		return c.requestBody(ctx, method, endpoint, nil, nil, contentType)
 	}
 
-	req.SetBasicAuth(c.Key, "")
-	req.Header.Set("User-Agent", "Invoiced Go/"+version)
-	req.Header.Set("Content-Type", requestType)
-
-	resp, err := c.client.Do(req)
-
-	return resp, err
+	return c.requestBody(ctx, method, endpoint, bytes.NewReader(payload), payload, contentType)
 }
 
-func (c *Api) Create(endpoint string, requestData interface{}, responseData interface{}) error {
-	b, err := json.Marshal(requestData)
-	if err != nil {
-		return err
+// requestBody is like request but reads the body from a reader, which lets
+// uploads be streamed. A body that is not a bytes.Reader cannot be replayed,
+// so such requests are never retried.
+func (c *Api) requestBody(ctx context.Context, method string, endpoint string, body io.Reader, payload []byte, contentType string) (*http.Response, error) {
+	url := c.baseUrl + endpoint
+	if strings.HasPrefix(endpoint, "https://") || strings.HasPrefix(endpoint, "http://") {
+		url = endpoint
 	}
 
-	body := bytes.NewBuffer(b)
-
-	resp, err := c.post(endpoint, body)
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	apiError := checkStatusForError(resp.StatusCode, resp.Body)
-
-	if apiError != nil {
-		return apiError
+	// the API key is only ever sent to the API itself, not to other hosts
+	// such as the one serving PDFs
+	if strings.HasPrefix(url, c.baseUrl+"/") {
+		req.SetBasicAuth(c.Key, "")
 	}
+	req.Header.Set("User-Agent", c.userAgent())
 
-	if responseData == nil {
-		return nil
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
 	}
 
-	err = pushDataIntoStruct(responseData, resp.Body)
-
-	if err != nil {
-		return err
+	if method == "POST" {
+		c.setIdempotencyKey(req)
 	}
 
-	return nil
-}
+	start := time.Now()
+	resp, err := c.handler()(&Request{Request: req, Endpoint: endpoint, Payload: payload})
+	c.captureResponse(ctx, resp, time.Since(start))
 
-// CreateFormFile is a convenience wrapper around CreatePart. It creates
-// a new form-data header with the provided field name and file name.
-func createFormFile(w *multipart.Writer, fieldname, filename string, fileType string) (io.Writer, error) {
-	h := make(textproto.MIMEHeader)
-	h.Set("Content-Disposition",
-		fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
-			escapeQuotes(fieldname), escapeQuotes(filename)))
-	h.Set("Content-Type", fileType)
-	return w.CreatePart(h)
+	return resp, err
 }
 
-var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
-
-func escapeQuotes(s string) string {
-	return quoteEscaper.Replace(s)
+func (c *Api) Create(endpoint string, requestData interface{}, responseData interface{}) error {
+	return c.CreateCtx(context.Background(), endpoint, requestData, responseData)
 }
 
-func (c *Api) Upload(endpoint string, filePath string, fileParamName string, fileParams map[string]string, fileType string, responseData interface{}) error {
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-	file, err := os.Open(filePath)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	part, err := createFormFile(writer, fileParamName, filepath.Base(filePath), fileType)
-
-	if err != nil {
+// CreateCtx is like Create but carries ctx through to the HTTP request.
+func (c *Api) CreateCtx(ctx context.Context, endpoint string, requestData interface{}, responseData interface{}) error {
+	if err := c.validate(requestData, true); err != nil {
 		return err
 	}
-	_, err = io.Copy(part, file)
 
+	b, err := json.Marshal(requestData)
 	if err != nil {
 		return err
 	}
 
-	for key, val := range fileParams {
-		_ = writer.WriteField(key, val)
-	}
-	err = writer.Close()
-
+	resp, err := c.post(ctx, endpoint, b)
 	if err != nil {
 		return err
 	}
 
-	resp, err := c.postWithFormData(endpoint, body, writer.FormDataContentType())
-
-	if err != nil {
-		return err
-	}
+	defer closeBody(resp)
 
-	apiError := checkStatusForError(resp.StatusCode, resp.Body)
+	apiError := checkStatusForError(resp)
 
 	if apiError != nil {
 		return apiError
@@ -313,12 +336,19 @@ func (c *Api) Upload(endpoint string, filePath string, fileParamName string, fil
 }
 
 func (c *Api) Delete(endpoint string) error {
-	resp, err := c.deleteRequest(endpoint)
+	return c.DeleteCtx(context.Background(), endpoint)
+}
+
+// DeleteCtx is like Delete but carries ctx through to the HTTP request.
+func (c *Api) DeleteCtx(ctx context.Context, endpoint string) error {
+	resp, err := c.deleteRequest(ctx, endpoint)
 	if err != nil {
 		return err
 	}
 
-	apiError := checkStatusForError(resp.StatusCode, resp.Body)
+	defer closeBody(resp)
+
+	apiError := checkStatusForError(resp)
 
 	if apiError != nil {
 		return apiError
@@ -328,19 +358,28 @@ func (c *Api) Delete(endpoint string) error {
 }
 
 func (c *Api) Update(endpoint string, requestData interface{}, responseData interface{}) error {
+	return c.UpdateCtx(context.Background(), endpoint, requestData, responseData)
+}
+
+// UpdateCtx is like Update but carries ctx through to the HTTP request.
+func (c *Api) UpdateCtx(ctx context.Context, endpoint string, requestData interface{}, responseData interface{}) error {
+	if err := c.validate(requestData, false); err != nil {
+		return err
+	}
+
 	b, err := json.Marshal(requestData)
 	if err != nil {
 		return err
 	}
 
-	body := bytes.NewBuffer(b)
-
-	resp, err := c.patch(endpoint, body)
+	resp, err := c.patch(ctx, endpoint, b)
 	if err != nil {
 		return err
 	}
 
-	apiError := checkStatusForError(resp.StatusCode, resp.Body)
+	defer closeBody(resp)
+
+	apiError := checkStatusForError(resp)
 
 	if apiError != nil {
 		return apiError
@@ -356,12 +395,20 @@ func (c *Api) Update(endpoint string, requestData interface{}, responseData inte
 }
 
 func (c *Api) PostWithoutData(endpoint string, responseData interface{}) error {
-	resp, err := c.post(endpoint, nil)
+	return c.PostWithoutDataCtx(context.Background(), endpoint, responseData)
+}
+
+// PostWithoutDataCtx is like PostWithoutData but carries ctx through to the
+// HTTP request.
+func (c *Api) PostWithoutDataCtx(ctx context.Context, endpoint string, responseData interface{}) error {
+	resp, err := c.post(ctx, endpoint, nil)
 	if err != nil {
 		return err
 	}
 
-	apiError := checkStatusForError(resp.StatusCode, resp.Body)
+	defer closeBody(resp)
+
+	apiError := checkStatusForError(resp)
 
 	if apiError != nil {
 		return apiError
@@ -377,14 +424,19 @@ func (c *Api) PostWithoutData(endpoint string, responseData interface{}) error {
 }
 
 func (c *Api) Count(endpoint string) (int64, error) {
-	resp, err := c.get(endpoint)
+	return c.CountCtx(context.Background(), endpoint)
+}
+
+// CountCtx is like Count but carries ctx through to the HTTP request.
+func (c *Api) CountCtx(ctx context.Context, endpoint string) (int64, error) {
+	resp, err := c.get(ctx, endpoint)
 	if err != nil {
 		return -1, err
 	}
 
-	defer resp.Body.Close()
+	defer closeBody(resp)
 
-	err = checkStatusForError(resp.StatusCode, resp.Body)
+	err = checkStatusForError(resp)
 	if err != nil {
 		return -1, err
 	}
@@ -400,14 +452,29 @@ func (c *Api) Count(endpoint string) (int64, error) {
 }
 
 func (c *Api) Get(endpoint string, endpointData interface{}) (string, error) {
+	return c.GetCtx(context.Background(), endpoint, endpointData)
+}
+
+// GetCtx is like Get but carries ctx through to the HTTP request. Since every
+// page of a ListAll loop goes through GetCtx, a cancelled ctx stops the loop
+// before the next page is requested.
+func (c *Api) GetCtx(ctx context.Context, endpoint string, endpointData interface{}) (string, error) {
+	nextURL, _, err := c.getPage(ctx, endpoint, endpointData)
+	return nextURL, err
+}
+
+// getPage decodes one page of a list into endpointData and returns the
+// endpoint of the next page, or "" on the last page, along with the
+// response headers.
+func (c *Api) getPage(ctx context.Context, endpoint string, endpointData interface{}) (string, http.Header, error) {
 	nextURL := ""
 
-	resp, err := c.get(endpoint)
+	resp, err := c.get(ctx, endpoint)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	defer resp.Body.Close()
+	defer closeBody(resp)
 
 	link := resp.Header.Get("Link")
 
@@ -419,17 +486,17 @@ func (c *Api) Get(endpoint string, endpointData interface{}) (string, error) {
 		}
 	}
 
-	apiError := checkStatusForError(resp.StatusCode, resp.Body)
+	apiError := checkStatusForError(resp)
 
 	if apiError != nil {
-		return "", apiError
+		return "", nil, apiError
 	}
 
 	err = pushDataIntoStruct(endpointData, resp.Body)
 
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	return strings.Replace(nextURL, c.baseUrl, "", -1), nil
+	return strings.Replace(nextURL, c.baseUrl, "", -1), resp.Header, nil
 }
@@ -2,6 +2,7 @@ package api
 
 import (
 	"github.com/Invoiced/invoiced-go/v2"
+	"github.com/Invoiced/invoiced-go/v2/archive"
 	"github.com/Invoiced/invoiced-go/v2/charge"
 	"github.com/Invoiced/invoiced-go/v2/chasing"
 	"github.com/Invoiced/invoiced-go/v2/coupon"
@@ -27,6 +28,7 @@ import (
 
 type Client struct {
 	Api                     *invoiced.Api
+	Archive                 archive.Client
 	Charge                  charge.Client
 	ChasingCadence          chasing.Client
 	Coupon                  coupon.Client
@@ -50,11 +52,15 @@ type Client struct {
 	WebhookAttempt webhookattempt.Client
 }
 
-func New(key string, sandbox bool) *Client {
-	apiClient := invoiced.New(key, sandbox)
+func New(key string, sandbox bool, opts ...invoiced.Option) *Client {
+	return newClient(invoiced.New(key, sandbox, opts...))
+}
 
+// newClient returns the Client whose resource clients all use apiClient.
+func newClient(apiClient *invoiced.Api) *Client {
 	return &Client{
 		Api:                     apiClient,
+		Archive:                 archive.Client{Api: apiClient},
 		Charge:                  charge.Client{Api: apiClient},
 		ChasingCadence:          chasing.Client{Api: apiClient},
 		Coupon:                  coupon.Client{Api: apiClient},
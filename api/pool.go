@@ -0,0 +1,204 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/Invoiced/invoiced-go/v2"
+)
+
+// ErrUnknownTenant is returned when a Pool has no client for a tenant.
+var ErrUnknownTenant = errors.New("invoiced: unknown tenant")
+
+// Pool holds one Client per tenant, e.g. per business unit with its own API
+// key. Its clients share one HTTP client, and so one pool of connections,
+// while everything else is per tenant: a rate limit set with
+// invoiced.WithRateLimit gives each API key its own limiter, whereas
+// invoiced.WithRateLimiter would share one limiter between all of them.
+type Pool struct {
+	mu      sync.RWMutex
+	sandbox bool
+	opts    []invoiced.Option
+	http    *http.Client
+	tenants map[string]*tenant
+}
+
+type tenant struct {
+	client *Client
+}
+
+// NewPool creates an empty pool. The options apply to the client of every
+// tenant. The HTTP settings among them, such as WithTransport, WithTimeout
+// or WithProxy, are resolved once into the shared HTTP client.
+func NewPool(sandbox bool, opts ...invoiced.Option) *Pool {
+	return &Pool{
+		sandbox: sandbox,
+		opts:    opts,
+		http:    invoiced.New("", sandbox, opts...).HTTPClient(),
+		tenants: make(map[string]*tenant),
+	}
+}
+
+// Add registers or replaces the client of a tenant. The options are applied
+// after those of the pool, e.g. to give one account a different rate limit.
+// A tenant given HTTP settings, such as WithTimeout or WithTLSConfig, gets
+// its own HTTP client built from the options of the pool and its own,
+// instead of sharing the one of the pool.
+func (p *Pool) Add(name, key string, opts ...invoiced.Option) *Client {
+	all := make([]invoiced.Option, 0, len(p.opts)+len(opts))
+	all = append(all, p.opts...)
+	all = append(all, opts...)
+
+	client := New(key, p.sandbox, all...)
+	if !configuresHTTP(opts) {
+		client.Api.SetHTTPClient(p.http)
+	}
+
+	p.mu.Lock()
+	p.tenants[name] = &tenant{client: client}
+	p.mu.Unlock()
+
+	return client
+}
+
+// configuresHTTP reports whether the options change the HTTP client, which
+// they do when the client they build on their own is not the default one.
+func configuresHTTP(opts []invoiced.Option) bool {
+	c := invoiced.New("", false, opts...).HTTPClient()
+
+	return c.Transport != nil || c.Timeout != 0 || c.Jar != nil || c.CheckRedirect != nil
+}
+
+// Get returns the client of a tenant.
+func (p *Pool) Get(name string) (*Client, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	t, ok := p.tenants[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTenant, name)
+	}
+
+	return t.client, nil
+}
+
+// Remove unregisters a tenant. Calls already using its client are not
+// affected.
+func (p *Pool) Remove(name string) {
+	p.mu.Lock()
+	delete(p.tenants, name)
+	p.mu.Unlock()
+}
+
+// Rotate replaces the API key of a tenant without downtime: the client of
+// the tenant is copied with the new key and swapped in, so calls made from
+// then on use the new key while calls in flight finish with the old one. The
+// copy keeps every setting of the old client, including its HTTP client,
+// rate limiter and middleware, so the rotation neither drops connections nor
+// refills the rate limit.
+func (p *Pool) Rotate(name, key string) (*Client, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	t, ok := p.tenants[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTenant, name)
+	}
+
+	apiClient := *t.client.Api
+	apiClient.Key = key
+
+	rotated := &tenant{client: newClient(&apiClient)}
+	p.tenants[name] = rotated
+
+	return rotated.client, nil
+}
+
+// Tenants returns the names of the tenants, sorted.
+func (p *Pool) Tenants() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	names := make([]string, 0, len(p.tenants))
+	for name := range p.tenants {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Result is the outcome of a FanOut call for one tenant.
+type Result[T any] struct {
+	Tenant string
+	Value  T
+	Err    error
+}
+
+// Results are the outcomes of a FanOut call, sorted by tenant.
+type Results[T any] []Result[T]
+
+// Err joins the errors of the failed tenants, each prefixed with the name
+// of its tenant, or returns nil if every call succeeded.
+func (r Results[T]) Err() error {
+	var errs []error
+	for _, result := range r {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("tenant %s: %w", result.Tenant, result.Err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Values returns the values of the tenants whose call succeeded.
+func (r Results[T]) Values() map[string]T {
+	values := make(map[string]T, len(r))
+	for _, result := range r {
+		if result.Err == nil {
+			values[result.Tenant] = result.Value
+		}
+	}
+
+	return values
+}
+
+// FanOut calls fn concurrently with the client of every tenant and collects
+// the results. A failing tenant does not stop the others; cancel ctx for
+// that.
+//
+//	results := api.FanOut(ctx, pool, func(ctx context.Context, tenant string, client *api.Client) (int64, error) {
+//		return client.Invoice.CountCtx(ctx)
+//	})
+func FanOut[T any](ctx context.Context, p *Pool, fn func(ctx context.Context, tenant string, client *Client) (T, error)) Results[T] {
+	p.mu.RLock()
+	results := make(Results[T], 0, len(p.tenants))
+	clients := make([]*Client, 0, len(p.tenants))
+	for name, t := range p.tenants {
+		results = append(results, Result[T]{Tenant: name})
+		clients = append(clients, t.client)
+	}
+	p.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i].Value, results[i].Err = fn(ctx, results[i].Tenant, clients[i])
+		}()
+	}
+
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Tenant < results[j].Tenant
+	})
+
+	return results
+}
@@ -0,0 +1,191 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Invoiced/invoiced-go/v2"
+)
+
+func newPoolServer(t *testing.T, keys *sync.Map) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, _, _ := r.BasicAuth()
+		keys.Store(key, true)
+
+		if key == "bad" {
+			http.Error(w, `{"type":"authentication_error","message":"invalid key"}`, http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("X-Total-Count", strconv.Itoa(len(key)))
+		_, _ = w.Write([]byte("[]"))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestPool(t *testing.T) {
+	var keys sync.Map
+	server := newPoolServer(t, &keys)
+
+	pool := NewPool(false, invoiced.WithBaseUrl(server.URL), invoiced.WithRateLimit(invoiced.RateLimit{RequestsPerSecond: 100}))
+	east := pool.Add("east", "key-east")
+	west := pool.Add("west", "key-west-2")
+
+	if east.Api.HTTPClient() != west.Api.HTTPClient() {
+		t.Fatal("Expected the tenants to share an HTTP client")
+	}
+
+	if _, err := pool.Get("north"); !errors.Is(err, ErrUnknownTenant) {
+		t.Fatal("Expected ErrUnknownTenant, got", err)
+	}
+
+	client, err := pool.Get("east")
+	if err != nil || client != east {
+		t.Fatal("Unexpected client", client, err)
+	}
+
+	results := FanOut(context.Background(), pool, func(ctx context.Context, tenant string, client *Client) (int64, error) {
+		return client.Invoice.CountCtx(ctx)
+	})
+
+	if err := results.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 2 || results[0].Tenant != "east" || results[0].Value != 8 || results[1].Tenant != "west" || results[1].Value != 10 {
+		t.Fatal("Unexpected results", results)
+	}
+
+	if _, ok := keys.Load("key-east"); !ok {
+		t.Fatal("The key of the tenant was not used")
+	}
+}
+
+func TestPoolRotate(t *testing.T) {
+	var keys sync.Map
+	server := newPoolServer(t, &keys)
+
+	pool := NewPool(false, invoiced.WithBaseUrl(server.URL), invoiced.WithRateLimit(invoiced.RateLimit{RequestsPerSecond: 0.001, Burst: 2, FailFast: true}))
+	old := pool.Add("east", "old-key")
+
+	if _, err := old.Invoice.Count(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := pool.Rotate("north", "new-key"); !errors.Is(err, ErrUnknownTenant) {
+		t.Fatal("Expected ErrUnknownTenant, got", err)
+	}
+
+	rotated, err := pool.Rotate("east", "new-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if current, _ := pool.Get("east"); current != rotated || rotated.Api.Key != "new-key" || old.Api.Key != "old-key" {
+		t.Fatal("The key was not rotated")
+	}
+
+	if rotated.Api.HTTPClient() != old.Api.HTTPClient() {
+		t.Fatal("Expected the rotated client to share the HTTP client")
+	}
+
+	if _, err := rotated.Invoice.Count(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := keys.Load("new-key"); !ok {
+		t.Fatal("The new key was not used")
+	}
+
+	if _, err := rotated.Invoice.Count(); !errors.Is(err, invoiced.ErrRateLimitExceeded) {
+		t.Fatal("Expected the rotated client to keep the rate limiter, got", err)
+	}
+}
+
+func TestPoolConcurrentRotate(t *testing.T) {
+	pool := NewPool(false)
+	pool.Add("east", "key-0")
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := pool.Rotate("east", "key-"+strconv.Itoa(i)); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if client, _ := pool.Get("east"); client.Api.Key == "key-0" {
+		t.Fatal("Expected the key to be rotated")
+	}
+}
+
+func TestPoolTenantHTTPOptions(t *testing.T) {
+	var keys sync.Map
+	server := newPoolServer(t, &keys)
+
+	pool := NewPool(false, invoiced.WithBaseUrl(server.URL), invoiced.WithTimeout(time.Minute))
+	east := pool.Add("east", "key-east")
+	west := pool.Add("west", "key-west", invoiced.WithTimeout(time.Second))
+
+	if east.Api.HTTPClient() == west.Api.HTTPClient() {
+		t.Fatal("Expected the tenant with HTTP options to get its own HTTP client")
+	}
+
+	if east.Api.HTTPClient().Timeout != time.Minute || west.Api.HTTPClient().Timeout != time.Second {
+		t.Fatal("Unexpected timeouts", east.Api.HTTPClient().Timeout, west.Api.HTTPClient().Timeout)
+	}
+
+	rotated, err := pool.Rotate("west", "key-west-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rotated.Api.HTTPClient() != west.Api.HTTPClient() {
+		t.Fatal("Expected the rotated client to keep the HTTP client of the tenant")
+	}
+
+	if _, err := rotated.Invoice.Count(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFanOutErrors(t *testing.T) {
+	var keys sync.Map
+	server := newPoolServer(t, &keys)
+
+	pool := NewPool(false, invoiced.WithBaseUrl(server.URL))
+	pool.Add("east", "key-east")
+	pool.Add("west", "bad")
+
+	results := FanOut(context.Background(), pool, func(ctx context.Context, tenant string, client *Client) (int64, error) {
+		return client.Invoice.CountCtx(ctx)
+	})
+
+	err := results.Err()
+	if !errors.Is(err, invoiced.ErrAuthentication) {
+		t.Fatal("Expected an authentication error, got", err)
+	}
+
+	values := results.Values()
+	if len(values) != 1 || values["east"] != 8 {
+		t.Fatal("Unexpected values", values)
+	}
+
+	pool.Remove("west")
+	if tenants := pool.Tenants(); len(tenants) != 1 || tenants[0] != "east" {
+		t.Fatal("Unexpected tenants", tenants)
+	}
+}
@@ -0,0 +1,427 @@
+// Package archive bundles the PDFs of the invoices and credit notes issued
+// in a period into a ZIP archive, along with a CSV manifest, e.g. for an
+// audit.
+package archive
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Invoiced/invoiced-go/v2"
+	"github.com/Invoiced/invoiced-go/v2/creditnote"
+	"github.com/Invoiced/invoiced-go/v2/invoice"
+)
+
+const (
+	TypeInvoice    = "invoice"
+	TypeCreditNote = "credit_note"
+
+	// ManifestName is the name of the CSV manifest in the archive.
+	ManifestName = "manifest.csv"
+
+	defaultConcurrency = 4
+)
+
+type Client struct {
+	*invoiced.Api
+}
+
+type ExportRequest struct {
+	// StartDate and EndDate bound the dates of the documents, as Unix
+	// timestamps. Either can be zero to leave that side open.
+	StartDate int64
+	EndDate   int64
+
+	// Dir is where the PDFs are downloaded before being archived. PDFs
+	// already in Dir, left by an interrupted export of the same period, are
+	// not downloaded again unless their document was updated since. Dir is
+	// not removed afterwards. When empty, a temporary directory is used and
+	// removed.
+	Dir string
+
+	// Concurrency is the number of PDFs downloaded at once, 4 by default.
+	Concurrency int
+}
+
+// Document is an archived document, as listed in the manifest.
+type Document struct {
+	Type     string
+	Id       int64
+	Number   string
+	Customer int64
+	Date     int64
+	Currency string
+	Total    float64
+	Balance  float64
+
+	// UpdatedAt is when the document was last updated, which tells whether
+	// a PDF left by an interrupted export is still current.
+	UpdatedAt int64
+
+	// File is the path of the PDF in the archive, or empty when the API
+	// provided no PDF for the document.
+	File string
+
+	// SHA256 is the hex encoded SHA-256 checksum of the PDF.
+	SHA256 string
+
+	pdfUrl string
+}
+
+type ExportResult struct {
+	Documents []*Document
+
+	// Downloaded is the number of PDFs downloaded by this export, as
+	// opposed to found in ExportRequest.Dir.
+	Downloaded int
+}
+
+func (c *Client) Export(request *ExportRequest, w io.Writer) (*ExportResult, error) {
+	return c.ExportCtx(context.Background(), request, w)
+}
+
+// ExportCtx lists the invoices and credit notes dated within the requested
+// period, downloads their PDFs and writes them to w as a ZIP archive with a
+// manifest. If the export fails, calling it again with the same Dir picks
+// up where it stopped.
+func (c *Client) ExportCtx(ctx context.Context, request *ExportRequest, w io.Writer) (_ *ExportResult, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "archive.export")
+	defer func() { op.End(err) }()
+
+	documents, err := c.listDocuments(ctx, request.StartDate, request.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := request.Dir
+	if dir == "" {
+		dir, err = os.MkdirTemp("", "invoiced-archive")
+		if err != nil {
+			return nil, err
+		}
+
+		defer os.RemoveAll(dir)
+	} else if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	concurrency := request.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	downloaded, err := c.download(ctx, documents, dir, concurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writeArchive(w, documents, dir); err != nil {
+		return nil, err
+	}
+
+	return &ExportResult{Documents: documents, Downloaded: downloaded}, nil
+}
+
+func (c *Client) listDocuments(ctx context.Context, startDate, endDate int64) ([]*Document, error) {
+	invoices, err := (&invoice.Client{Api: c.Api}).ListAllInvoicesStartEndDateCtx(ctx, nil, nil, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	creditNotes, err := (&creditnote.Client{Api: c.Api}).ListAllStartEndDateCtx(ctx, nil, nil, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	documents := make([]*Document, 0, len(invoices)+len(creditNotes))
+
+	for _, inv := range invoices {
+		documents = append(documents, &Document{
+			Type:      TypeInvoice,
+			Id:        inv.Id,
+			Number:    inv.Number,
+			Customer:  inv.Customer.Id,
+			Date:      inv.Date,
+			Currency:  inv.Currency,
+			Total:     inv.Total,
+			Balance:   inv.Balance,
+			UpdatedAt: inv.UpdatedAt,
+			pdfUrl:    inv.PdfUrl,
+		})
+	}
+
+	for _, creditNote := range creditNotes {
+		documents = append(documents, &Document{
+			Type:      TypeCreditNote,
+			Id:        creditNote.Id,
+			Number:    creditNote.Number,
+			Customer:  creditNote.Customer.Id,
+			Date:      creditNote.Date,
+			Currency:  creditNote.Currency,
+			Total:     creditNote.Total,
+			Balance:   creditNote.Balance,
+			UpdatedAt: creditNote.UpdatedAt,
+			pdfUrl:    creditNote.PdfUrl,
+		})
+	}
+
+	sort.SliceStable(documents, func(i, j int) bool {
+		if documents[i].Type != documents[j].Type {
+			return documents[i].Type == TypeInvoice
+		}
+
+		if documents[i].Date != documents[j].Date {
+			return documents[i].Date < documents[j].Date
+		}
+
+		return documents[i].Number < documents[j].Number
+	})
+
+	assignFileNames(documents)
+
+	return documents, nil
+}
+
+// assignFileNames names each PDF after its document number, adding the id
+// when two documents share a number.
+func assignFileNames(documents []*Document) {
+	used := make(map[string]bool)
+
+	for _, document := range documents {
+		if document.pdfUrl == "" {
+			continue
+		}
+
+		folder := "invoices/"
+		if document.Type == TypeCreditNote {
+			folder = "credit_notes/"
+		}
+
+		name := sanitize(document.Number)
+		if name == "" || used[folder+name] {
+			name += "-" + strconv.FormatInt(document.Id, 10)
+		}
+
+		used[folder+name] = true
+		document.File = folder + name + ".pdf"
+	}
+}
+
+var unsafeChars = strings.NewReplacer("/", "_", "\\", "_", ":", "_", "..", "_")
+
+func sanitize(number string) string {
+	return strings.TrimSpace(unsafeChars.Replace(number))
+}
+
+// workFile is the path of the PDF of a document in the work directory. It
+// includes the update time of the document, so that the PDF of a document
+// updated since an interrupted export is downloaded again.
+func workFile(dir string, document *Document) string {
+	return filepath.Join(dir, workFilePrefix(document)+strconv.FormatInt(document.UpdatedAt, 10)+".pdf")
+}
+
+func workFilePrefix(document *Document) string {
+	return document.Type + "-" + strconv.FormatInt(document.Id, 10) + "-"
+}
+
+// removeStale removes the PDFs of earlier versions of a document.
+func removeStale(dir string, document *Document) error {
+	stale, err := filepath.Glob(filepath.Join(dir, workFilePrefix(document)+"*.pdf"))
+	if err != nil {
+		return err
+	}
+
+	current := workFile(dir, document)
+	for _, path := range stale {
+		if path != current {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// download fetches the PDFs that are not in dir yet and returns how many it
+// downloaded. Downloads are written atomically, so a PDF present in dir is
+// complete.
+func (c *Client) download(ctx context.Context, documents []*Document, dir string, concurrency int) (int, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg         sync.WaitGroup
+		mu         sync.Mutex
+		firstErr   error
+		downloaded int
+	)
+
+	sem := make(chan struct{}, concurrency)
+
+	for _, document := range documents {
+		if document.File == "" {
+			continue
+		}
+
+		path := workFile(dir, document)
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+
+		if err := removeStale(dir, document); err != nil {
+			cancel()
+			wg.Wait()
+
+			return downloaded, err
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			_, err := c.Api.DownloadToFileCtx(ctx, document.pdfUrl, path)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("downloading %s %s: %w", document.Type, document.Number, err)
+					cancel()
+				}
+				return
+			}
+
+			downloaded++
+		}()
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return downloaded, firstErr
+	}
+
+	return downloaded, ctx.Err()
+}
+
+func writeArchive(w io.Writer, documents []*Document, dir string) error {
+	archive := zip.NewWriter(w)
+
+	for _, document := range documents {
+		if document.File == "" {
+			continue
+		}
+
+		if err := addFile(archive, document, workFile(dir, document)); err != nil {
+			return err
+		}
+	}
+
+	manifest, err := archive.Create(ManifestName)
+	if err != nil {
+		return err
+	}
+
+	if err := writeManifest(manifest, documents); err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+func addFile(archive *zip.Writer, document *Document, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	entry, err := archive.CreateHeader(&zip.FileHeader{
+		Name:     document.File,
+		Method:   zip.Deflate,
+		Modified: time.Unix(document.Date, 0).UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	checksum := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(entry, checksum), f); err != nil {
+		return err
+	}
+
+	document.SHA256 = hex.EncodeToString(checksum.Sum(nil))
+
+	return nil
+}
+
+func writeManifest(w io.Writer, documents []*Document) error {
+	manifest := csv.NewWriter(w)
+
+	_ = manifest.Write([]string{"type", "number", "customer", "date", "currency", "total", "balance", "file", "sha256"})
+
+	for _, document := range documents {
+		total, err := formatAmount(document.Total, document.Currency)
+		if err != nil {
+			return fmt.Errorf("total of %s %s: %w", document.Type, document.Number, err)
+		}
+
+		balance, err := formatAmount(document.Balance, document.Currency)
+		if err != nil {
+			return fmt.Errorf("balance of %s %s: %w", document.Type, document.Number, err)
+		}
+
+		_ = manifest.Write([]string{
+			document.Type,
+			document.Number,
+			strconv.FormatInt(document.Customer, 10),
+			time.Unix(document.Date, 0).UTC().Format("2006-01-02"),
+			document.Currency,
+			total,
+			balance,
+			document.File,
+			document.SHA256,
+		})
+	}
+
+	manifest.Flush()
+
+	return manifest.Error()
+}
+
+// formatAmount formats an amount with the decimal places of its currency,
+// e.g. 2 for USD, 0 for JPY and 3 for KWD.
+func formatAmount(amount float64, currency string) (string, error) {
+	m, err := invoiced.CheckedMoneyFromFloat(amount, currency)
+	if err != nil {
+		return "", err
+	}
+
+	return m.RoundToCurrency(invoiced.RoundHalfEven).String(), nil
+}
@@ -0,0 +1,246 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Invoiced/invoiced-go/v2"
+)
+
+type archiveServer struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	downloads map[string]int
+	failing   string
+	updatedAt int64
+}
+
+func newArchiveServer(t *testing.T) *archiveServer {
+	s := &archiveServer{downloads: make(map[string]int)}
+
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/invoices":
+			if r.URL.Query().Get("start_date") != "1704067200" || r.URL.Query().Get("end_date") != "1711929599" {
+				http.Error(w, "unexpected period", http.StatusBadRequest)
+				return
+			}
+
+			s.mu.Lock()
+			updatedAt := s.updatedAt
+			s.mu.Unlock()
+
+			_ = json.NewEncoder(w).Encode(invoiced.Invoices{
+				{Id: 2, Number: "INV-0002", Customer: invoiced.Ref[invoiced.Customer, int64](10), Date: 1706745600, Currency: "usd", Total: 50, Balance: 0, PdfUrl: s.URL + "/pdf/inv-2"},
+				{Id: 1, Number: "INV-0001", Customer: invoiced.Ref[invoiced.Customer, int64](10), Date: 1704067200, Currency: "usd", Total: 100.5, Balance: 25.25, UpdatedAt: updatedAt, PdfUrl: s.URL + "/pdf/inv-1"},
+				{Id: 3, Number: "INV-0003", Customer: invoiced.Ref[invoiced.Customer, int64](11), Date: 1709251200, Currency: "kwd", Total: 10.125},
+			})
+		case r.URL.Path == "/credit_notes":
+			_ = json.NewEncoder(w).Encode(invoiced.CreditNotes{
+				{Id: 7, Number: "CN/0001", Customer: invoiced.Ref[invoiced.Customer, int64](11), Date: 1709251200, Currency: "jpy", Total: 2000, PdfUrl: s.URL + "/pdf/cn-7"},
+			})
+		case strings.HasPrefix(r.URL.Path, "/pdf/"):
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			if s.failing == r.URL.Path {
+				http.Error(w, "unavailable", http.StatusServiceUnavailable)
+				return
+			}
+
+			s.downloads[r.URL.Path]++
+
+			_, _ = w.Write(pdf(r.URL.Path))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func pdf(path string) []byte {
+	return []byte("%PDF-1.4 " + path)
+}
+
+func checksum(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func exportRequest(dir string) *ExportRequest {
+	return &ExportRequest{StartDate: 1704067200, EndDate: 1711929599, Dir: dir}
+}
+
+func TestExport(t *testing.T) {
+	server := newArchiveServer(t)
+	client := Client{invoiced.NewMockApi("api key", server.Server)}
+
+	var buf bytes.Buffer
+	result, err := client.Export(exportRequest(""), &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Documents) != 4 || result.Downloaded != 3 {
+		t.Fatal("Unexpected result", result.Documents, result.Downloaded)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := make(map[string][]byte)
+	var names []string
+	for _, f := range archive.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := io.ReadAll(r)
+		_ = r.Close()
+
+		files[f.Name] = b
+		names = append(names, f.Name)
+	}
+
+	expectedNames := "invoices/INV-0001.pdf,invoices/INV-0002.pdf,credit_notes/CN_0001.pdf,manifest.csv"
+	if strings.Join(names, ",") != expectedNames {
+		t.Fatal("Unexpected files", names)
+	}
+
+	if !bytes.Equal(files["invoices/INV-0001.pdf"], pdf("/pdf/inv-1")) {
+		t.Fatal("Unexpected PDF", string(files["invoices/INV-0001.pdf"]))
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(files[ManifestName])).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := [][]string{
+		{"type", "number", "customer", "date", "currency", "total", "balance", "file", "sha256"},
+		{"invoice", "INV-0001", "10", "2024-01-01", "usd", "100.50", "25.25", "invoices/INV-0001.pdf", checksum(pdf("/pdf/inv-1"))},
+		{"invoice", "INV-0002", "10", "2024-02-01", "usd", "50.00", "0.00", "invoices/INV-0002.pdf", checksum(pdf("/pdf/inv-2"))},
+		{"invoice", "INV-0003", "11", "2024-03-01", "kwd", "10.125", "0.000", "", ""},
+		{"credit_note", "CN/0001", "11", "2024-03-01", "jpy", "2000", "0", "credit_notes/CN_0001.pdf", checksum(pdf("/pdf/cn-7"))},
+	}
+
+	if len(records) != len(expected) {
+		t.Fatal("Unexpected manifest", records)
+	}
+
+	for i := range expected {
+		if strings.Join(records[i], ",") != strings.Join(expected[i], ",") {
+			t.Fatal("Unexpected manifest row", records[i], expected[i])
+		}
+	}
+}
+
+func TestExportResume(t *testing.T) {
+	server := newArchiveServer(t)
+	server.failing = "/pdf/inv-2"
+	client := Client{invoiced.NewMockApi("api key", server.Server)}
+
+	dir := t.TempDir()
+
+	if _, err := client.Export(exportRequest(dir), io.Discard); err == nil {
+		t.Fatal("Expected the export to fail")
+	}
+
+	saved, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server.mu.Lock()
+	server.failing = ""
+	server.mu.Unlock()
+
+	result, err := client.Export(exportRequest(dir), io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Downloaded != 3-len(saved) {
+		t.Fatal("Expected only the missing PDFs to be downloaded, got", result.Downloaded, "with", len(saved), "saved")
+	}
+
+	if server.downloads["/pdf/inv-2"] != 1 {
+		t.Fatal("Unexpected downloads", server.downloads)
+	}
+}
+
+func TestExportUpdatedDocument(t *testing.T) {
+	server := newArchiveServer(t)
+	client := Client{invoiced.NewMockApi("api key", server.Server)}
+
+	dir := t.TempDir()
+
+	if _, err := client.Export(exportRequest(dir), io.Discard); err != nil {
+		t.Fatal(err)
+	}
+
+	server.mu.Lock()
+	server.updatedAt = 1710000000
+	server.mu.Unlock()
+
+	result, err := client.Export(exportRequest(dir), io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Downloaded != 1 || server.downloads["/pdf/inv-1"] != 2 {
+		t.Fatal("Expected the updated document to be downloaded again", result.Downloaded, server.downloads)
+	}
+
+	saved, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(saved) != 3 {
+		t.Fatal("Expected the stale PDF to be removed", saved)
+	}
+}
+
+func TestWriteManifestInvalidAmount(t *testing.T) {
+	documents := []*Document{{Type: TypeInvoice, Number: "INV-1", Currency: "usd", Total: math.NaN()}}
+
+	if err := writeManifest(io.Discard, documents); err == nil || !strings.Contains(err.Error(), "INV-1") {
+		t.Fatal("Expected the NaN total to fail", err)
+	}
+}
+
+func TestAssignFileNames(t *testing.T) {
+	documents := []*Document{
+		{Type: TypeInvoice, Id: 1, Number: "INV-1", pdfUrl: "a"},
+		{Type: TypeInvoice, Id: 2, Number: "INV-1", pdfUrl: "b"},
+		{Type: TypeCreditNote, Id: 3, Number: "INV-1", pdfUrl: "c"},
+		{Type: TypeInvoice, Id: 4, Number: "../..", pdfUrl: "d"},
+	}
+
+	assignFileNames(documents)
+
+	expected := []string{"invoices/INV-1.pdf", "invoices/INV-1-2.pdf", "credit_notes/INV-1.pdf", "invoices/___.pdf"}
+	for i, document := range documents {
+		if document.File != expected[i] {
+			t.Fatal("Unexpected file name", document.File, expected[i])
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package charge
 
 import (
+	"context"
 	"github.com/Invoiced/invoiced-go/v2"
 	"strconv"
 )
@@ -9,14 +10,27 @@ type Client struct {
 	*invoiced.Api
 }
 
+func (c *Client) resource() invoiced.Resource[invoiced.Charge, invoiced.ChargeRequest, int64] {
+	return invoiced.NewResource[invoiced.Charge, invoiced.ChargeRequest, int64](c.Api, "charge", "/charges")
+}
+
 func (c *Client) Create(request *invoiced.ChargeRequest) (*invoiced.Charge, error) {
-	resp := new(invoiced.Charge)
-	err := c.Api.Create("/charges", request, resp)
-	return resp, err
+	return c.CreateCtx(context.Background(), request)
+}
+
+func (c *Client) CreateCtx(ctx context.Context, request *invoiced.ChargeRequest) (*invoiced.Charge, error) {
+	return c.resource().CreateCtx(ctx, request)
 }
 
 func (c *Client) Refund(chargeId int64, request *invoiced.RefundRequest) (*invoiced.Refund, error) {
+	return c.RefundCtx(context.Background(), chargeId, request)
+}
+
+func (c *Client) RefundCtx(ctx context.Context, chargeId int64, request *invoiced.RefundRequest) (_ *invoiced.Refund, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "charge.refund")
+	defer func() { op.End(err) }()
+
 	refund := new(invoiced.Refund)
-	err := c.Api.Create("/charges/"+strconv.FormatInt(chargeId, 10)+"/refunds", request, refund)
+	err = c.Api.CreateCtx(ctx, "/charges/"+strconv.FormatInt(chargeId, 10)+"/refunds", request, refund)
 	return refund, err
 }
@@ -13,7 +13,7 @@ func TestCreateCharge(t *testing.T) {
 	mockChargeResponseID := int64(1523)
 	mockChargeResponse := new(invoiced.Payment)
 	mockChargeResponse.Id = mockChargeResponseID
-	mockChargeResponse.Customer = 234112
+	mockChargeResponse.Customer.Id = 234112
 	mockChargeResponse.Reference = "234"
 
 	mockChargeResponse.CreatedAt = time.Now().UnixNano()
@@ -16,20 +16,20 @@ type ChargeRequest struct {
 }
 
 type Charge struct {
-	Id             int64          `json:"id"`
-	Object         string         `json:"object"`
-	Customer       int64          `json:"customer"`
-	Status         string         `json:"status"`
-	Gateway        string         `json:"gateway"`
-	GatewayId      string         `json:"gateway_id"`
-	PaymentSource  *PaymentSource `json:"payment_source"`
-	Currency       string         `json:"currency"`
-	Amount         float64        `json:"amount"`
-	FailureMessage string         `json:"failure_message"`
-	AmountRefunded float64        `json:"amount_refunded"`
-	Refunded       bool           `json:"refunded"`
-	Refunds        []Refund       `json:"refunds"`
-	Disputed       bool           `json:"disputed"`
-	CreatedAt      int64          `json:"created_at"`
-	UpdatedAt      int64          `json:"updated_at"`
+	Id             int64                            `json:"id"`
+	Object         string                           `json:"object"`
+	Customer       Expandable[Customer, int64]      `json:"customer"`
+	Status         string                           `json:"status"`
+	Gateway        string                           `json:"gateway"`
+	GatewayId      string                           `json:"gateway_id"`
+	PaymentSource  Expandable[PaymentSource, int64] `json:"payment_source"`
+	Currency       string                           `json:"currency"`
+	Amount         float64                          `json:"amount"`
+	FailureMessage string                           `json:"failure_message"`
+	AmountRefunded float64                          `json:"amount_refunded"`
+	Refunded       bool                             `json:"refunded"`
+	Refunds        []Refund                         `json:"refunds"`
+	Disputed       bool                             `json:"disputed"`
+	CreatedAt      int64                            `json:"created_at"`
+	UpdatedAt      int64                            `json:"updated_at"`
 }
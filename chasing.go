@@ -20,15 +20,15 @@ type ChasingCadence struct {
 }
 
 type ChasingStep struct {
-	Action          string  `json:"action"`
-	AssignedUserId  *int64  `json:"assigned_user_id"`
-	CreatedAt       int64   `json:"created_at"`
-	EmailTemplateId *string `json:"email_template_id"`
-	Id              int64   `json:"id"`
-	Name            string  `json:"name"`
-	Schedule        string  `json:"schedule"`
-	SmsTemplateId   *string `json:"sms_template_id"`
-	UpdatedAt       int64   `json:"updated_at"`
+	Action          ChasingAction `json:"action"`
+	AssignedUserId  *int64        `json:"assigned_user_id"`
+	CreatedAt       int64         `json:"created_at"`
+	EmailTemplateId *string       `json:"email_template_id"`
+	Id              int64         `json:"id"`
+	Name            string        `json:"name"`
+	Schedule        string        `json:"schedule"`
+	SmsTemplateId   *string       `json:"sms_template_id"`
+	UpdatedAt       int64         `json:"updated_at"`
 }
 
 type ChasingCadences []*ChasingCadence
@@ -1,6 +1,7 @@
 package chasing
 
 import (
+	"context"
 	"github.com/Invoiced/invoiced-go/v2"
 )
 
@@ -8,25 +9,62 @@ type Client struct {
 	*invoiced.Api
 }
 
+func (c *Client) resource() invoiced.Resource[invoiced.ChasingCadence, struct{}, int64] {
+	return invoiced.NewResource[invoiced.ChasingCadence, struct{}, int64](c.Api, "chasing_cadence", "/chasing_cadences")
+}
+
 func (c *Client) ListAll(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.ChasingCadences, error) {
-	endpoint := invoiced.AddFilterAndSort("/chasing_cadences", filter, sort)
+	return c.ListAllCtx(context.Background(), filter, sort)
+}
 
-	chasing := make(invoiced.ChasingCadences, 0)
+func (c *Client) ListAllCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.ChasingCadences, error) {
+	return c.resource().ListAllCtx(ctx, filter, sort)
+}
 
-NEXT:
-	tmpChasing := make(invoiced.ChasingCadences, 0)
+func (c *Client) Iterate(filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.ChasingCadence] {
+	return c.IterateCtx(context.Background(), filter, sort)
+}
 
-	endpointTmp, err := c.Api.Get(endpoint, &tmpChasing)
+func (c *Client) IterateCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.ChasingCadence] {
+	return c.resource().IterateCtx(ctx, filter, sort)
+}
 
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) List(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.ChasingCadences, string, error) {
+	return c.ListCtx(context.Background(), filter, sort)
+}
 
-	chasing = append(chasing, tmpChasing...)
+func (c *Client) ListCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.ChasingCadences, string, error) {
+	return c.resource().ListCtx(ctx, filter, sort)
+}
 
-	if endpointTmp != "" {
-		goto NEXT
-	}
+func (c *Client) ListQuery(query *invoiced.Query) (invoiced.ChasingCadences, string, error) {
+	return c.ListQueryCtx(context.Background(), query)
+}
+
+func (c *Client) ListQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.ChasingCadences, string, error) {
+	return c.resource().ListQueryCtx(ctx, query)
+}
+
+func (c *Client) ListAllQuery(query *invoiced.Query) (invoiced.ChasingCadences, error) {
+	return c.ListAllQueryCtx(context.Background(), query)
+}
+
+func (c *Client) ListAllQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.ChasingCadences, error) {
+	return c.resource().ListAllQueryCtx(ctx, query)
+}
+
+func (c *Client) IterateQuery(query *invoiced.Query) *invoiced.Iter[*invoiced.ChasingCadence] {
+	return c.IterateQueryCtx(context.Background(), query)
+}
+
+func (c *Client) IterateQueryCtx(ctx context.Context, query *invoiced.Query) *invoiced.Iter[*invoiced.ChasingCadence] {
+	return c.resource().IterateQueryCtx(ctx, query)
+}
+
+func (c *Client) Count() (int64, error) {
+	return c.CountCtx(context.Background())
+}
 
-	return chasing, nil
+func (c *Client) CountCtx(ctx context.Context) (int64, error) {
+	return c.resource().CountCtx(ctx)
 }
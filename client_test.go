@@ -1,8 +1,20 @@
 package invoiced
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/Invoiced/invoiced-go/v2/invdmockserver"
 )
 
 func TestParseRawRelation(t *testing.T) {
@@ -117,3 +129,180 @@ func TestMakeEndpointSingular(t *testing.T) {
 		t.Fatal("Expect =>", singularEndpoint, " Got =>", correctSingularEndpoint)
 	}
 }
+
+func TestGetCtxDeadlineExceeded(t *testing.T) {
+	server, err := invdmockserver.New(200, new(Customer), "json", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	client := NewMockApi("whatever", server)
+
+	ctx, cancel := context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+
+	_, err = client.GetCtx(ctx, "/customers/1", new(Customer))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("Expected context.DeadlineExceeded, got", err)
+	}
+
+	_, err = client.GetCtx(context.Background(), "/customers/1", new(Customer))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+type trackedBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *trackedBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestResponseBodiesAreClosed(t *testing.T) {
+	var bodies []*trackedBody
+
+	client := New("whatever", false, WithTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		body := &trackedBody{Reader: strings.NewReader(`{"id":1234}`)}
+		bodies = append(bodies, body)
+
+		return &http.Response{StatusCode: 200, Header: http.Header{}, Body: body, Request: req}, nil
+	})))
+
+	file := filepath.Join(t.TempDir(), "upload.txt")
+	if err := os.WriteFile(file, []byte("hello"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := map[string]func() error{
+		"Create":          func() error { return client.Create("/customers", &CustomerRequest{}, new(Customer)) },
+		"Update":          func() error { return client.Update("/customers/1234", &CustomerRequest{}, new(Customer)) },
+		"Delete":          func() error { return client.Delete("/customers/1234") },
+		"PostWithoutData": func() error { return client.PostWithoutData("/invoices/1234/pay", new(Invoice)) },
+		"Upload":          func() error { return client.Upload("/files", file, "file", nil, "text/plain", new(File)) },
+		"Get":             func() error { _, err := client.Get("/customers/1234", new(Customer)); return err },
+	}
+
+	for name, call := range calls {
+		bodies = nil
+
+		if err := call(); err != nil {
+			t.Fatal(name, err)
+		}
+
+		if len(bodies) != 1 || !bodies[0].closed {
+			t.Fatal(name, "did not close the response body")
+		}
+	}
+}
+
+func TestErrorBodyIsCapped(t *testing.T) {
+	client := New("whatever", false, WithTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		body := strings.Repeat("x", 2*maxErrorBodySize)
+		return &http.Response{StatusCode: 502, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body)), Request: req}, nil
+	})))
+
+	err := client.Delete("/customers/1234")
+
+	var apiError *APIError
+	if !errors.As(err, &apiError) {
+		t.Fatal("Expected an APIError, got", err)
+	}
+
+	if len(apiError.Type) != maxErrorBodySize {
+		t.Fatal("Expected the error body to be capped, got", len(apiError.Type))
+	}
+}
+
+func TestPushDataIntoStructEmptyBody(t *testing.T) {
+	err := pushDataIntoStruct(new(Customer), strings.NewReader(""))
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("Expected an unexpected EOF, got", err)
+	}
+}
+
+func TestPushDataIntoStructList(t *testing.T) {
+	customers := Customers{{Id: 99}}
+
+	err := pushDataIntoStruct(&customers, strings.NewReader(`[{"id":1,"name":"a"},{"id":2,"name":"b"}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(customers) != 2 || customers[0].Id != 1 || customers[1].Name != "b" {
+		t.Fatal("Unexpected customers", customers)
+	}
+
+	if err := pushDataIntoStruct(&customers, strings.NewReader(`null`)); err != nil || customers != nil {
+		t.Fatal("Expected null to clear the list", customers, err)
+	}
+
+	if err := pushDataIntoStruct(&customers, strings.NewReader(`{"id":1}`)); err == nil {
+		t.Fatal("Expected an error when a list is not an array")
+	}
+
+	if err := pushDataIntoStruct(&customers, strings.NewReader(`[{"id":1},`)); err == nil {
+		t.Fatal("Expected an error for a truncated list")
+	}
+}
+
+func largeInvoicePage(b *testing.B) []byte {
+	invoices := make(Invoices, 100)
+	for i := range invoices {
+		invoices[i] = &Invoice{
+			Id:       int64(i),
+			Number:   "INV-" + strconv.Itoa(i),
+			Currency: "usd",
+			Items:    make([]LineItem, 10),
+		}
+
+		for j := range invoices[i].Items {
+			invoices[i].Items[j] = LineItem{Id: int64(j), Name: "Line item", Description: strings.Repeat("description ", 20)}
+		}
+	}
+
+	page, err := json.Marshal(invoices)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	return page
+}
+
+// BenchmarkDecodeBuffered decodes a page of 100 invoices the way responses
+// used to be decoded, reading the whole body before unmarshaling it.
+func BenchmarkDecodeBuffered(b *testing.B) {
+	page := largeInvoicePage(b)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(page)))
+
+	for i := 0; i < b.N; i++ {
+		body, err := io.ReadAll(bytes.NewReader(page))
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		invoices := make(Invoices, 0)
+		if err := json.Unmarshal(body, &invoices); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkDecodeStreaming decodes the same page with pushDataIntoStruct.
+func BenchmarkDecodeStreaming(b *testing.B) {
+	page := largeInvoicePage(b)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(page)))
+
+	for i := 0; i < b.N; i++ {
+		invoices := make(Invoices, 0)
+		if err := pushDataIntoStruct(&invoices, bytes.NewReader(page)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
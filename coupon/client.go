@@ -1,6 +1,7 @@
 package coupon
 
 import (
+	"context"
 	"github.com/Invoiced/invoiced-go/v2"
 )
 
@@ -8,47 +9,94 @@ type Client struct {
 	*invoiced.Api
 }
 
+func (c *Client) resource() invoiced.Resource[invoiced.Coupon, invoiced.CouponRequest, string] {
+	return invoiced.NewResource[invoiced.Coupon, invoiced.CouponRequest, string](c.Api, "coupon", "/coupons")
+}
+
 func (c *Client) Create(request *invoiced.CouponRequest) (*invoiced.Coupon, error) {
-	resp := new(invoiced.Coupon)
-	err := c.Api.Create("/coupons", request, resp)
-	return resp, err
+	return c.CreateCtx(context.Background(), request)
+}
+
+func (c *Client) CreateCtx(ctx context.Context, request *invoiced.CouponRequest) (*invoiced.Coupon, error) {
+	return c.resource().CreateCtx(ctx, request)
 }
 
 func (c *Client) Retrieve(id string) (*invoiced.Coupon, error) {
-	resp := new(invoiced.Coupon)
-	_, err := c.Api.Get("/coupons/"+id, resp)
-	return resp, err
+	return c.RetrieveCtx(context.Background(), id)
+}
+
+func (c *Client) RetrieveCtx(ctx context.Context, id string) (*invoiced.Coupon, error) {
+	return c.resource().RetrieveCtx(ctx, id)
 }
 
 func (c *Client) Update(id string, request *invoiced.CouponRequest) (*invoiced.Coupon, error) {
-	resp := new(invoiced.Coupon)
-	err := c.Api.Update("/coupons/"+id, request, resp)
-	return resp, err
+	return c.UpdateCtx(context.Background(), id, request)
+}
+
+func (c *Client) UpdateCtx(ctx context.Context, id string, request *invoiced.CouponRequest) (*invoiced.Coupon, error) {
+	return c.resource().UpdateCtx(ctx, id, request)
 }
 
 func (c *Client) Delete(id string) error {
-	return c.Api.Delete("/coupons/" + id)
+	return c.DeleteCtx(context.Background(), id)
+}
+
+func (c *Client) DeleteCtx(ctx context.Context, id string) error {
+	return c.resource().DeleteCtx(ctx, id)
 }
 
 func (c *Client) ListAll(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Coupons, error) {
-	endpoint := invoiced.AddFilterAndSort("/coupons", filter, sort)
+	return c.ListAllCtx(context.Background(), filter, sort)
+}
 
-	coupons := make(invoiced.Coupons, 0)
+func (c *Client) ListAllCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Coupons, error) {
+	return c.resource().ListAllCtx(ctx, filter, sort)
+}
 
-NEXT:
-	tmpCoupons := make(invoiced.Coupons, 0)
+func (c *Client) Iterate(filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.Coupon] {
+	return c.IterateCtx(context.Background(), filter, sort)
+}
 
-	endpointTmp, err := c.Api.Get(endpoint, &tmpCoupons)
+func (c *Client) IterateCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.Coupon] {
+	return c.resource().IterateCtx(ctx, filter, sort)
+}
 
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) List(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Coupons, string, error) {
+	return c.ListCtx(context.Background(), filter, sort)
+}
 
-	coupons = append(coupons, tmpCoupons...)
+func (c *Client) ListCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Coupons, string, error) {
+	return c.resource().ListCtx(ctx, filter, sort)
+}
+
+func (c *Client) ListQuery(query *invoiced.Query) (invoiced.Coupons, string, error) {
+	return c.ListQueryCtx(context.Background(), query)
+}
+
+func (c *Client) ListQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.Coupons, string, error) {
+	return c.resource().ListQueryCtx(ctx, query)
+}
+
+func (c *Client) ListAllQuery(query *invoiced.Query) (invoiced.Coupons, error) {
+	return c.ListAllQueryCtx(context.Background(), query)
+}
 
-	if endpointTmp != "" {
-		goto NEXT
-	}
+func (c *Client) ListAllQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.Coupons, error) {
+	return c.resource().ListAllQueryCtx(ctx, query)
+}
+
+func (c *Client) IterateQuery(query *invoiced.Query) *invoiced.Iter[*invoiced.Coupon] {
+	return c.IterateQueryCtx(context.Background(), query)
+}
+
+func (c *Client) IterateQueryCtx(ctx context.Context, query *invoiced.Query) *invoiced.Iter[*invoiced.Coupon] {
+	return c.resource().IterateQueryCtx(ctx, query)
+}
+
+func (c *Client) Count() (int64, error) {
+	return c.CountCtx(context.Background())
+}
 
-	return coupons, nil
+func (c *Client) CountCtx(ctx context.Context) (int64, error) {
+	return c.resource().CountCtx(ctx)
 }
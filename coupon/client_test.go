@@ -146,3 +146,38 @@ func TestCoupon_ListAll(t *testing.T) {
 		t.Fatal("Error messages do not match up")
 	}
 }
+
+func TestCoupon_ListAllPaginated(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(true,
+		invdmockserver.ScriptedResponse{
+			Status:  200,
+			Headers: map[string]string{"Link": `</coupons?page=1>; rel="self", </coupons?page=2>; rel="next"`},
+			Body:    invoiced.Coupons{{Id: "first"}},
+		},
+		invdmockserver.ScriptedResponse{
+			Status:  200,
+			Headers: map[string]string{"Link": `</coupons?page=2>; rel="self"`},
+			Body:    invoiced.Coupons{{Id: "second"}},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer server.Close()
+
+	client := Client{invoiced.NewMockApi("test api key", server.Server)}
+
+	coupons, err := client.ListAll(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(coupons) != 2 || coupons[1].Id != "second" {
+		t.Fatal("Unexpected coupons", coupons)
+	}
+
+	if len(server.Requests()) != 2 {
+		t.Fatal("Expected 2 requests, got", len(server.Requests()))
+	}
+}
@@ -1,5 +1,7 @@
 package invoiced
 
+import "encoding/json"
+
 type CreditBalanceAdjustmentRequest struct {
 	Amount   *float64 `json:"amount,omitempty"`
 	Currency *string  `json:"currency,omitempty"`
@@ -9,15 +11,31 @@ type CreditBalanceAdjustmentRequest struct {
 }
 
 type CreditBalanceAdjustment struct {
-	Amount    float64 `json:"amount,omitempty"`
-	CreatedAt int64   `json:"created_at,omitempty"`
-	Currency  string  `json:"currency,omitempty"`
-	Customer  int64   `json:"customer,omitempty"`
-	Date      int64   `json:"date,omitempty"`
-	ID        int64   `json:"id,omitempty"`
-	Notes     string  `json:"notes,omitempty"`
-	Object    string  `json:"object,omitempty"`
-	UpdatedAt int64   `json:"updated_at,omitempty"`
+	Amount    float64                     `json:"amount,omitempty"`
+	CreatedAt int64                       `json:"created_at,omitempty"`
+	Currency  string                      `json:"currency,omitempty"`
+	Customer  Expandable[Customer, int64] `json:"customer"`
+	Date      int64                       `json:"date,omitempty"`
+	ID        int64                       `json:"id,omitempty"`
+	Notes     string                      `json:"notes,omitempty"`
+	Object    string                      `json:"object,omitempty"`
+	UpdatedAt int64                       `json:"updated_at,omitempty"`
 }
 
 type CreditBalanceAdjustments []*CreditBalanceAdjustment
+
+// MarshalJSON leaves out the customer when it is not set.
+func (c CreditBalanceAdjustment) MarshalJSON() ([]byte, error) {
+	type adjustment CreditBalanceAdjustment
+
+	v := struct {
+		adjustment
+		Customer *Expandable[Customer, int64] `json:"customer,omitempty"`
+	}{adjustment: adjustment(c)}
+
+	if c.Customer.IsSet() {
+		v.Customer = &c.Customer
+	}
+
+	return json.Marshal(v)
+}
@@ -24,3 +24,15 @@ func TestUnmarshalBalanceAdjustmentJSON(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestMarshalBalanceAdjustmentWithoutCustomer(t *testing.T) {
+	b, err := json.Marshal(CreditBalanceAdjustment{Amount: 10})
+	if err != nil || string(b) != `{"amount":10}` {
+		t.Fatal("Expected the unset customer to be omitted", string(b), err)
+	}
+
+	b, err = json.Marshal(CreditBalanceAdjustment{Amount: 10, Customer: Ref[Customer, int64](15444)})
+	if err != nil || string(b) != `{"amount":10,"customer":15444}` {
+		t.Fatal("Unexpected JSON", string(b), err)
+	}
+}
@@ -2,7 +2,7 @@ package invoiced
 
 import (
 	"encoding/json"
-	"strconv"
+	"time"
 )
 
 type CreditNoteRequest struct {
@@ -25,34 +25,32 @@ type CreditNoteRequest struct {
 }
 
 type CreditNote struct {
-	Attachments   []int64                `json:"attachments"`
-	Balance       float64                `json:"balance"`
-	Closed        bool                   `json:"closed"`
-	CreatedAt     int64                  `json:"created_at"`
-	Currency      string                 `json:"currency"`
-	Customer      int64                  `json:"-"`
-	CustomerFull  *Customer              `json:"-"`
-	CustomerRaw   json.RawMessage        `json:"customer"`
-	Date          int64                  `json:"date"`
-	Discounts     []Discount             `json:"discounts"`
-	Draft         bool                   `json:"draft"`
-	Id            int64                  `json:"id"`
-	Invoice       int64                  `json:"invoice"`
-	Items         []LineItem             `json:"items"`
-	Metadata      map[string]interface{} `json:"metadata"`
-	Name          string                 `json:"name"`
-	Notes         string                 `json:"notes"`
-	Number        string                 `json:"number"`
-	Object        string                 `json:"object"`
-	Paid          bool                   `json:"paid"`
-	PdfUrl        string                 `json:"pdf_url"`
-	PurchaseOrder string                 `json:"purchase_order"`
-	Status        string                 `json:"status"`
-	Subtotal      float64                `json:"subtotal"`
-	Taxes         []Tax                  `json:"taxes"`
-	Total         float64                `json:"total"`
-	UpdatedAt     int64                  `json:"updated_at"`
-	Url           string                 `json:"url"`
+	Attachments   []int64                     `json:"attachments"`
+	Balance       float64                     `json:"balance"`
+	Closed        bool                        `json:"closed"`
+	CreatedAt     int64                       `json:"created_at"`
+	Currency      string                      `json:"currency"`
+	Customer      Expandable[Customer, int64] `json:"customer"`
+	Date          int64                       `json:"date"`
+	Discounts     []Discount                  `json:"discounts"`
+	Draft         bool                        `json:"draft"`
+	Id            int64                       `json:"id"`
+	Invoice       Expandable[Invoice, int64]  `json:"invoice"`
+	Items         []LineItem                  `json:"items"`
+	Metadata      map[string]interface{}      `json:"metadata"`
+	Name          string                      `json:"name"`
+	Notes         string                      `json:"notes"`
+	Number        string                      `json:"number"`
+	Object        string                      `json:"object"`
+	Paid          bool                        `json:"paid"`
+	PdfUrl        string                      `json:"pdf_url"`
+	PurchaseOrder string                      `json:"purchase_order"`
+	Status        CreditNoteStatus            `json:"status"`
+	Subtotal      float64                     `json:"subtotal"`
+	Taxes         []Tax                       `json:"taxes"`
+	Total         float64                     `json:"total"`
+	UpdatedAt     int64                       `json:"updated_at"`
+	Url           string                      `json:"url"`
 }
 
 type CreditNotes []*CreditNote
@@ -63,34 +61,26 @@ func (i *CreditNote) String() string {
 	return string(b)
 }
 
-func (i *CreditNote) UnmarshalJSON(data []byte) error {
-	type creditNote2 CreditNote
-	if err := json.Unmarshal(data, (*creditNote2)(i)); err != nil {
-		return err
-	}
-
-	rj := i.CustomerRaw
-
-	i.Customer, _ = strconv.ParseInt(string(rj), 10, 64)
-	customer := new(Customer)
-
-	err := json.Unmarshal(rj, customer)
+func (i *CreditNote) TotalMoney() (Money, error) {
+	return CheckedMoneyFromFloat(i.Total, i.Currency)
+}
 
-	if err == nil {
-		i.CustomerFull = customer
-		i.Customer = customer.Id
-	}
+func (i *CreditNote) SubtotalMoney() (Money, error) {
+	return CheckedMoneyFromFloat(i.Subtotal, i.Currency)
+}
 
-	return nil
+func (i *CreditNote) BalanceMoney() (Money, error) {
+	return CheckedMoneyFromFloat(i.Balance, i.Currency)
 }
 
-func (i *CreditNote) MarshalJSON() ([]byte, error) {
-	type creditNote2 CreditNote
-	i2 := (*creditNote2)(i)
+func (i *CreditNote) CreatedAtTime() time.Time {
+	return Time(i.CreatedAt)
+}
 
-	if i2.Customer > 0 {
-		i2.CustomerRaw = []byte(strconv.FormatInt(i2.Customer, 10))
-	}
+func (i *CreditNote) DateTime() time.Time {
+	return Time(i.Date)
+}
 
-	return json.Marshal(i2)
+func (i *CreditNote) UpdatedAtTime() time.Time {
+	return Time(i.UpdatedAt)
 }
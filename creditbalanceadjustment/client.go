@@ -1,55 +1,102 @@
 package creditbalanceadjustment
 
 import (
+	"context"
 	"github.com/Invoiced/invoiced-go/v2"
-	"strconv"
 )
 
 type Client struct {
 	*invoiced.Api
 }
 
+func (c *Client) resource() invoiced.Resource[invoiced.CreditBalanceAdjustment, invoiced.CreditBalanceAdjustmentRequest, int64] {
+	return invoiced.NewResource[invoiced.CreditBalanceAdjustment, invoiced.CreditBalanceAdjustmentRequest, int64](c.Api, "credit_balance_adjustment", "/credit_balance_adjustments")
+}
+
 func (c *Client) Create(request *invoiced.CreditBalanceAdjustmentRequest) (*invoiced.CreditBalanceAdjustment, error) {
-	resp := new(invoiced.CreditBalanceAdjustment)
-	err := c.Api.Create("/credit_balance_adjustments", request, resp)
-	return resp, err
+	return c.CreateCtx(context.Background(), request)
+}
+
+func (c *Client) CreateCtx(ctx context.Context, request *invoiced.CreditBalanceAdjustmentRequest) (*invoiced.CreditBalanceAdjustment, error) {
+	return c.resource().CreateCtx(ctx, request)
 }
 
 func (c *Client) Retrieve(id int64) (*invoiced.CreditBalanceAdjustment, error) {
-	resp := new(invoiced.CreditBalanceAdjustment)
-	_, err := c.Api.Get("/credit_balance_adjustments/"+strconv.FormatInt(id, 10), resp)
-	return resp, err
+	return c.RetrieveCtx(context.Background(), id)
+}
+
+func (c *Client) RetrieveCtx(ctx context.Context, id int64) (*invoiced.CreditBalanceAdjustment, error) {
+	return c.resource().RetrieveCtx(ctx, id)
 }
 
 func (c *Client) Update(id int64, request *invoiced.CreditBalanceAdjustmentRequest) (*invoiced.CreditBalanceAdjustment, error) {
-	resp := new(invoiced.CreditBalanceAdjustment)
-	err := c.Api.Update("/credit_balance_adjustments/"+strconv.FormatInt(id, 10), request, resp)
-	return resp, err
+	return c.UpdateCtx(context.Background(), id, request)
+}
+
+func (c *Client) UpdateCtx(ctx context.Context, id int64, request *invoiced.CreditBalanceAdjustmentRequest) (*invoiced.CreditBalanceAdjustment, error) {
+	return c.resource().UpdateCtx(ctx, id, request)
 }
 
 func (c *Client) Delete(id int64) error {
-	return c.Api.Delete("/credit_balance_adjustments/" + strconv.FormatInt(id, 10))
+	return c.DeleteCtx(context.Background(), id)
+}
+
+func (c *Client) DeleteCtx(ctx context.Context, id int64) error {
+	return c.resource().DeleteCtx(ctx, id)
 }
 
 func (c *Client) ListAll(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.CreditBalanceAdjustments, error) {
-	endpoint := invoiced.AddFilterAndSort("/credit_balance_adjustments", filter, sort)
+	return c.ListAllCtx(context.Background(), filter, sort)
+}
 
-	adjustments := make(invoiced.CreditBalanceAdjustments, 0)
+func (c *Client) ListAllCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.CreditBalanceAdjustments, error) {
+	return c.resource().ListAllCtx(ctx, filter, sort)
+}
 
-NEXT:
-	tmpAdjustments := make(invoiced.CreditBalanceAdjustments, 0)
+func (c *Client) Iterate(filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.CreditBalanceAdjustment] {
+	return c.IterateCtx(context.Background(), filter, sort)
+}
 
-	endpointTmp, err := c.Api.Get(endpoint, &tmpAdjustments)
+func (c *Client) IterateCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.CreditBalanceAdjustment] {
+	return c.resource().IterateCtx(ctx, filter, sort)
+}
 
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) List(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.CreditBalanceAdjustments, string, error) {
+	return c.ListCtx(context.Background(), filter, sort)
+}
 
-	adjustments = append(adjustments, tmpAdjustments...)
+func (c *Client) ListCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.CreditBalanceAdjustments, string, error) {
+	return c.resource().ListCtx(ctx, filter, sort)
+}
+
+func (c *Client) ListQuery(query *invoiced.Query) (invoiced.CreditBalanceAdjustments, string, error) {
+	return c.ListQueryCtx(context.Background(), query)
+}
+
+func (c *Client) ListQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.CreditBalanceAdjustments, string, error) {
+	return c.resource().ListQueryCtx(ctx, query)
+}
+
+func (c *Client) ListAllQuery(query *invoiced.Query) (invoiced.CreditBalanceAdjustments, error) {
+	return c.ListAllQueryCtx(context.Background(), query)
+}
 
-	if endpointTmp != "" {
-		goto NEXT
-	}
+func (c *Client) ListAllQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.CreditBalanceAdjustments, error) {
+	return c.resource().ListAllQueryCtx(ctx, query)
+}
+
+func (c *Client) IterateQuery(query *invoiced.Query) *invoiced.Iter[*invoiced.CreditBalanceAdjustment] {
+	return c.IterateQueryCtx(context.Background(), query)
+}
+
+func (c *Client) IterateQueryCtx(ctx context.Context, query *invoiced.Query) *invoiced.Iter[*invoiced.CreditBalanceAdjustment] {
+	return c.resource().IterateQueryCtx(ctx, query)
+}
+
+func (c *Client) Count() (int64, error) {
+	return c.CountCtx(context.Background())
+}
 
-	return adjustments, nil
+func (c *Client) CountCtx(ctx context.Context) (int64, error) {
+	return c.resource().CountCtx(ctx)
 }
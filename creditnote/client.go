@@ -1,38 +1,58 @@
 package creditnote
 
 import (
+	"context"
 	"github.com/Invoiced/invoiced-go/v2"
+	"io"
 	"strconv"
+	"time"
 )
 
 type Client struct {
 	*invoiced.Api
 }
 
+func (c *Client) resource() invoiced.Resource[invoiced.CreditNote, invoiced.CreditNoteRequest, int64] {
+	return invoiced.NewResource[invoiced.CreditNote, invoiced.CreditNoteRequest, int64](c.Api, "credit_note", "/credit_notes")
+}
+
 func (c *Client) Create(request *invoiced.CreditNoteRequest) (*invoiced.CreditNote, error) {
-	resp := new(invoiced.CreditNote)
-	err := c.Api.Create("/credit_notes", request, resp)
-	return resp, err
+	return c.CreateCtx(context.Background(), request)
+}
+
+func (c *Client) CreateCtx(ctx context.Context, request *invoiced.CreditNoteRequest) (*invoiced.CreditNote, error) {
+	return c.resource().CreateCtx(ctx, request)
 }
 
 func (c *Client) Retrieve(id int64) (*invoiced.CreditNote, error) {
-	resp := new(invoiced.CreditNote)
-	_, err := c.Api.Get("/credit_notes/"+strconv.FormatInt(id, 10), resp)
-	return resp, err
+	return c.RetrieveCtx(context.Background(), id)
+}
+
+func (c *Client) RetrieveCtx(ctx context.Context, id int64) (*invoiced.CreditNote, error) {
+	return c.resource().RetrieveCtx(ctx, id)
 }
 
 func (c *Client) Update(id int64, request *invoiced.CreditNoteRequest) (*invoiced.CreditNote, error) {
-	resp := new(invoiced.CreditNote)
-	err := c.Api.Update("/credit_notes/"+strconv.FormatInt(id, 10), request, resp)
-	return resp, err
+	return c.UpdateCtx(context.Background(), id, request)
 }
 
-func (c *Client) Void(id int64) (*Client, error) {
-	resp := new(Client)
+func (c *Client) UpdateCtx(ctx context.Context, id int64, request *invoiced.CreditNoteRequest) (*invoiced.CreditNote, error) {
+	return c.resource().UpdateCtx(ctx, id, request)
+}
+
+func (c *Client) Void(id int64) (*invoiced.CreditNote, error) {
+	return c.VoidCtx(context.Background(), id)
+}
+
+func (c *Client) VoidCtx(ctx context.Context, id int64) (_ *invoiced.CreditNote, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "credit_note.void")
+	defer func() { op.End(err) }()
+
+	resp := new(invoiced.CreditNote)
 
 	endpoint := "/credit_notes/" + strconv.FormatInt(id, 10) + "/void"
 
-	err := c.Api.PostWithoutData(endpoint, resp)
+	err = c.Api.PostWithoutDataCtx(ctx, endpoint, resp)
 	if err != nil {
 		return nil, err
 	}
@@ -41,59 +61,149 @@ func (c *Client) Void(id int64) (*Client, error) {
 }
 
 func (c *Client) Delete(id int64) error {
-	return c.Api.Delete("/credit_notes/" + strconv.FormatInt(id, 10))
+	return c.DeleteCtx(context.Background(), id)
+}
+
+func (c *Client) DeleteCtx(ctx context.Context, id int64) error {
+	return c.resource().DeleteCtx(ctx, id)
 }
 
 func (c *Client) Count() (int64, error) {
-	return c.Api.Count("/credit_notes")
+	return c.CountCtx(context.Background())
+}
+
+func (c *Client) CountCtx(ctx context.Context) (int64, error) {
+	return c.resource().CountCtx(ctx)
 }
 
 func (c *Client) ListAll(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.CreditNotes, error) {
-	endpoint := invoiced.AddFilterAndSort("/credit_notes", filter, sort)
+	return c.ListAllCtx(context.Background(), filter, sort)
+}
 
-	creditNotes := make(invoiced.CreditNotes, 0)
+func (c *Client) ListAllCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.CreditNotes, error) {
+	return c.resource().ListAllCtx(ctx, filter, sort)
+}
 
-NEXT:
-	tmpCreditNotes := make(invoiced.CreditNotes, 0)
+func (c *Client) Iterate(filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.CreditNote] {
+	return c.IterateCtx(context.Background(), filter, sort)
+}
 
-	endpoint, err := c.Api.Get(endpoint, &tmpCreditNotes)
+func (c *Client) IterateCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.CreditNote] {
+	return c.resource().IterateCtx(ctx, filter, sort)
+}
 
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) List(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.CreditNotes, string, error) {
+	return c.ListCtx(context.Background(), filter, sort)
+}
+
+func (c *Client) ListCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.CreditNotes, string, error) {
+	return c.resource().ListCtx(ctx, filter, sort)
+}
 
-	creditNotes = append(creditNotes, tmpCreditNotes...)
+func (c *Client) ListQuery(query *invoiced.Query) (invoiced.CreditNotes, string, error) {
+	return c.ListQueryCtx(context.Background(), query)
+}
+
+func (c *Client) ListQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.CreditNotes, string, error) {
+	return c.resource().ListQueryCtx(ctx, query)
+}
 
-	if endpoint != "" {
-		goto NEXT
+func (c *Client) ListAllQuery(query *invoiced.Query) (invoiced.CreditNotes, error) {
+	return c.ListAllQueryCtx(context.Background(), query)
+}
+
+func (c *Client) ListAllQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.CreditNotes, error) {
+	return c.resource().ListAllQueryCtx(ctx, query)
+}
+
+func (c *Client) IterateQuery(query *invoiced.Query) *invoiced.Iter[*invoiced.CreditNote] {
+	return c.IterateQueryCtx(context.Background(), query)
+}
+
+func (c *Client) IterateQueryCtx(ctx context.Context, query *invoiced.Query) *invoiced.Iter[*invoiced.CreditNote] {
+	return c.resource().IterateQueryCtx(ctx, query)
+}
+
+// ListAllStartEndDate is a shorthand for ListAllQuery with Query.StartDate
+// and Query.EndDate.
+func (c *Client) ListAllStartEndDate(filter *invoiced.Filter, sort *invoiced.Sort, startDate, endDate int64) (invoiced.CreditNotes, error) {
+	return c.ListAllStartEndDateCtx(context.Background(), filter, sort, startDate, endDate)
+}
+
+func (c *Client) ListAllStartEndDateCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort, startDate, endDate int64) (_ invoiced.CreditNotes, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "credit_note.list_all_start_end_date")
+	defer func() { op.End(err) }()
+
+	url := invoiced.AddFilterAndSort("/credit_notes", filter, sort)
+
+	if startDate > 0 {
+		url = invoiced.AddQueryParameter(url, "start_date", strconv.FormatInt(startDate, 10))
+	}
+
+	if endDate > 0 {
+		url = invoiced.AddQueryParameter(url, "end_date", strconv.FormatInt(endDate, 10))
 	}
 
-	return creditNotes, nil
+	return invoiced.CollectAll[invoiced.CreditNotes](ctx, c.Api, url)
+}
+
+// ListAllStartEndTime is a shorthand for ListAllQuery with Query.DateRange.
+func (c *Client) ListAllStartEndTime(filter *invoiced.Filter, sort *invoiced.Sort, start, end time.Time) (invoiced.CreditNotes, error) {
+	return c.ListAllStartEndTimeCtx(context.Background(), filter, sort, start, end)
+}
+
+func (c *Client) ListAllStartEndTimeCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort, start, end time.Time) (invoiced.CreditNotes, error) {
+	return c.ListAllStartEndDateCtx(ctx, filter, sort, invoiced.Unix(start), invoiced.Unix(end))
 }
 
 func (c *Client) ListAttachments(id int64) (invoiced.Files, error) {
-	endpoint := "/credit_notes/" + strconv.FormatInt(id, 10) + "/attachments"
+	return c.ListAttachmentsCtx(context.Background(), id)
+}
 
-	files := make(invoiced.Files, 0)
+func (c *Client) ListAttachmentsCtx(ctx context.Context, id int64) (invoiced.Files, error) {
+	return c.ListAttachmentsQueryCtx(ctx, id, nil)
+}
 
-NEXT:
-	tempFiles := make(invoiced.Files, 0)
+// ListAttachmentsQuery is like ListAttachments, with the parameters of a Query.
+func (c *Client) ListAttachmentsQuery(id int64, query *invoiced.Query) (invoiced.Files, error) {
+	return c.ListAttachmentsQueryCtx(context.Background(), id, query)
+}
 
-	endpoint, err := c.Api.Get(endpoint, &tempFiles)
+func (c *Client) ListAttachmentsQueryCtx(ctx context.Context, id int64, query *invoiced.Query) (_ invoiced.Files, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "credit_note.list_attachments")
+	defer func() { op.End(err) }()
 
+	endpoint, err := query.Endpoint("/credit_notes/" + strconv.FormatInt(id, 10) + "/attachments")
 	if err != nil {
 		return nil, err
 	}
 
-	files = append(files, tempFiles...)
+	return invoiced.CollectAll[invoiced.Files](ctx, c.Api, endpoint)
+}
 
-	if endpoint != "" {
-		goto NEXT
-	}
+func (c *Client) SendEmail(id int64, request *invoiced.SendEmailRequest) error {
+	return c.SendEmailCtx(context.Background(), id, request)
+}
 
-	return files, nil
+func (c *Client) SendEmailCtx(ctx context.Context, id int64, request *invoiced.SendEmailRequest) (err error) {
+	ctx, op := c.Api.StartOperation(ctx, "credit_note.send_email")
+	defer func() { op.End(err) }()
+
+	return c.Api.CreateCtx(ctx, "/credit_notes/"+strconv.FormatInt(id, 10)+"/emails", request, nil)
 }
 
-func (c *Client) SendEmail(id int64, request *invoiced.SendEmailRequest) error {
-	return c.Api.Create("/credit_notes/" + strconv.FormatInt(id, 10) + "/emails", request, nil)
+func (c *Client) DownloadPDF(id int64, w io.Writer) (*invoiced.Download, error) {
+	return c.DownloadPDFCtx(context.Background(), id, w)
+}
+
+func (c *Client) DownloadPDFCtx(ctx context.Context, id int64, w io.Writer) (_ *invoiced.Download, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "credit_note.download_pdf")
+	defer func() { op.End(err) }()
+
+	creditNote := new(invoiced.CreditNote)
+	if _, err = c.Api.GetCtx(ctx, c.resource().Endpoint(id), creditNote); err != nil {
+		return nil, err
+	}
+
+	return c.Api.DownloadCtx(ctx, creditNote.PdfUrl, w)
 }
@@ -1,8 +1,10 @@
 package customer
 
 import (
+	"context"
 	"errors"
 	"github.com/Invoiced/invoiced-go/v2"
+	"io"
 	"strconv"
 )
 
@@ -10,40 +12,71 @@ type Client struct {
 	*invoiced.Api
 }
 
+func (c *Client) resource() invoiced.Resource[invoiced.Customer, invoiced.CustomerRequest, int64] {
+	return invoiced.NewResource[invoiced.Customer, invoiced.CustomerRequest, int64](c.Api, "customer", "/customers")
+}
+
 func (c *Client) Create(request *invoiced.CustomerRequest) (*invoiced.Customer, error) {
-	resp := new(invoiced.Customer)
-	err := c.Api.Create("/customers", request, resp)
-	return resp, err
+	return c.CreateCtx(context.Background(), request)
+}
+
+func (c *Client) CreateCtx(ctx context.Context, request *invoiced.CustomerRequest) (*invoiced.Customer, error) {
+	return c.resource().CreateCtx(ctx, request)
 }
 
 func (c *Client) Retrieve(id int64) (*invoiced.Customer, error) {
-	resp := new(invoiced.Customer)
-	_, err := c.Api.Get("/customers/"+strconv.FormatInt(id, 10), resp)
-	return resp, err
+	return c.RetrieveCtx(context.Background(), id)
+}
+
+func (c *Client) RetrieveCtx(ctx context.Context, id int64) (*invoiced.Customer, error) {
+	return c.resource().RetrieveCtx(ctx, id)
 }
 
 func (c *Client) RetrieveAccountingSyncStatus(id int64) (*invoiced.AccountingSyncStatus, error) {
+	return c.RetrieveAccountingSyncStatusCtx(context.Background(), id)
+}
+
+func (c *Client) RetrieveAccountingSyncStatusCtx(ctx context.Context, id int64) (_ *invoiced.AccountingSyncStatus, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "customer.retrieve_accounting_sync_status")
+	defer func() { op.End(err) }()
+
 	resp := new(invoiced.AccountingSyncStatus)
-	_, err := c.Api.Get("/customers/"+strconv.FormatInt(id, 10)+"/accounting_sync_status", resp)
+	_, err = c.Api.GetCtx(ctx, "/customers/"+strconv.FormatInt(id, 10)+"/accounting_sync_status", resp)
 	return resp, err
 }
 
 func (c *Client) Update(id int64, request *invoiced.CustomerRequest) (*invoiced.Customer, error) {
-	endpoint := "/customers/" + strconv.FormatInt(id, 10)
-	resp := new(invoiced.Customer)
-	err := c.Api.Update(endpoint, request, resp)
-	return resp, err
+	return c.UpdateCtx(context.Background(), id, request)
+}
+
+func (c *Client) UpdateCtx(ctx context.Context, id int64, request *invoiced.CustomerRequest) (*invoiced.Customer, error) {
+	return c.resource().UpdateCtx(ctx, id, request)
 }
 
 func (c *Client) Delete(id int64) error {
-	return c.Api.Delete("/customers/" + strconv.FormatInt(id, 10))
+	return c.DeleteCtx(context.Background(), id)
+}
+
+func (c *Client) DeleteCtx(ctx context.Context, id int64) error {
+	return c.resource().DeleteCtx(ctx, id)
 }
 
 func (c *Client) Count() (int64, error) {
-	return c.Api.Count("/customers")
+	return c.CountCtx(context.Background())
+}
+
+func (c *Client) CountCtx(ctx context.Context) (int64, error) {
+	return c.resource().CountCtx(ctx)
 }
 
 func (c *Client) ListAllConnectedPaymentSource(filter *invoiced.Filter, sort *invoiced.Sort, paymentMethodConnected bool) (invoiced.Customers, error) {
+	return c.ListAllConnectedPaymentSourceCtx(context.Background(), filter, sort, paymentMethodConnected)
+}
+
+func (c *Client) ListAllConnectedPaymentSourceCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort, paymentMethodConnected bool) (_ invoiced.Customers, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "customer.list_all_connected_payment_source")
+	defer func() { op.End(err) }()
+
 	endpoint := invoiced.AddFilterAndSort("/customers", filter, sort)
 
 	if paymentMethodConnected {
@@ -52,27 +85,16 @@ func (c *Client) ListAllConnectedPaymentSource(filter *invoiced.Filter, sort *in
 		endpoint = invoiced.AddQueryParameter(endpoint, "payment_source", "0")
 	}
 
-	customers := make(invoiced.Customers, 0)
-
-NEXT:
-	tmpCustomers := make(invoiced.Customers, 0)
-
-	endpoint, err := c.Api.Get(endpoint, &tmpCustomers)
-
-	if err != nil {
-		return nil, err
-	}
-
-	customers = append(customers, tmpCustomers...)
-
-	if endpoint != "" {
-		goto NEXT
-	}
-
-	return customers, nil
+	return invoiced.CollectAll[invoiced.Customers](ctx, c.Api, endpoint)
 }
 
 func (c *Client) ListAllConnectedPaymentSourceByMetadata(filter *invoiced.Filter, metadataFilter *invoiced.Filter, sort *invoiced.Sort, paymentMethodConnected bool) (invoiced.Customers, error) {
+	return c.ListAllConnectedPaymentSourceByMetadataCtx(context.Background(), filter, metadataFilter, sort, paymentMethodConnected)
+}
+
+func (c *Client) ListAllConnectedPaymentSourceByMetadataCtx(ctx context.Context, filter *invoiced.Filter, metadataFilter *invoiced.Filter, sort *invoiced.Sort, paymentMethodConnected bool) (_ invoiced.Customers, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "customer.list_all_connected_payment_source_by_metadata")
+	defer func() { op.End(err) }()
 
 	endpoint, err := invoiced.AddFilterAndMetaFilterAndSort("/customers", filter, metadataFilter, sort)
 
@@ -86,64 +108,72 @@ func (c *Client) ListAllConnectedPaymentSourceByMetadata(filter *invoiced.Filter
 		endpoint = invoiced.AddQueryParameter(endpoint, "payment_source", "0")
 	}
 
-	customers := make(invoiced.Customers, 0)
-
-NEXT:
-	tmpCustomers := make(invoiced.Customers, 0)
-
-	endpoint, err = c.Api.Get(endpoint, &tmpCustomers)
-
-	if err != nil {
-		return nil, err
-	}
+	return invoiced.CollectAll[invoiced.Customers](ctx, c.Api, endpoint)
+}
 
-	customers = append(customers, tmpCustomers...)
+func (c *Client) ListAll(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Customers, error) {
+	return c.ListAllCtx(context.Background(), filter, sort)
+}
 
-	if endpoint != "" {
-		goto NEXT
-	}
+func (c *Client) ListAllCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Customers, error) {
+	return c.resource().ListAllCtx(ctx, filter, sort)
+}
 
-	return customers, nil
+func (c *Client) Iterate(filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.Customer] {
+	return c.IterateCtx(context.Background(), filter, sort)
 }
 
-func (c *Client) ListAll(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Customers, error) {
-	endpoint := invoiced.AddFilterAndSort("/customers", filter, sort)
+func (c *Client) IterateCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.Customer] {
+	return c.resource().IterateCtx(ctx, filter, sort)
+}
 
-	customers := make(invoiced.Customers, 0)
+func (c *Client) List(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Customers, string, error) {
+	return c.ListCtx(context.Background(), filter, sort)
+}
 
-NEXT:
-	tmpCustomers := make(invoiced.Customers, 0)
+func (c *Client) ListCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Customers, string, error) {
+	return c.resource().ListCtx(ctx, filter, sort)
+}
 
-	endpoint, err := c.Api.Get(endpoint, &tmpCustomers)
+func (c *Client) ListQuery(query *invoiced.Query) (invoiced.Customers, string, error) {
+	return c.ListQueryCtx(context.Background(), query)
+}
 
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) ListQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.Customers, string, error) {
+	return c.resource().ListQueryCtx(ctx, query)
+}
 
-	customers = append(customers, tmpCustomers...)
+func (c *Client) ListAllQuery(query *invoiced.Query) (invoiced.Customers, error) {
+	return c.ListAllQueryCtx(context.Background(), query)
+}
 
-	if endpoint != "" {
-		goto NEXT
-	}
+func (c *Client) ListAllQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.Customers, error) {
+	return c.resource().ListAllQueryCtx(ctx, query)
+}
 
-	return customers, nil
+func (c *Client) IterateQuery(query *invoiced.Query) *invoiced.Iter[*invoiced.Customer] {
+	return c.IterateQueryCtx(context.Background(), query)
 }
 
-func (c *Client) List(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Customers, string, error) {
-	endpoint := invoiced.AddFilterAndSort("/customers", filter, sort)
-	customers := make(invoiced.Customers, 0)
-	nextEndpoint, err := c.Api.Get(endpoint, &customers)
-	return customers, nextEndpoint, err
+func (c *Client) IterateQueryCtx(ctx context.Context, query *invoiced.Query) *invoiced.Iter[*invoiced.Customer] {
+	return c.resource().IterateQueryCtx(ctx, query)
 }
 
 func (c *Client) ListCustomerByNumber(customerNumber string) (*invoiced.Customer, error) {
+	return c.ListCustomerByNumberCtx(context.Background(), customerNumber)
+}
+
+func (c *Client) ListCustomerByNumberCtx(ctx context.Context, customerNumber string) (_ *invoiced.Customer, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "customer.list_customer_by_number")
+	defer func() { op.End(err) }()
+
 	filter := invoiced.NewFilter()
-	err := filter.Set("number", customerNumber)
+	err = filter.Set("number", customerNumber)
 	if err != nil {
 		return nil, err
 	}
 
-	customers, err := c.ListAll(filter, nil)
+	customers, err := invoiced.CollectAll[invoiced.Customers](ctx, c.Api, invoiced.AddFilterAndSort("/customers", filter, nil))
 	if err != nil {
 		return nil, err
 	}
@@ -157,13 +187,20 @@ func (c *Client) ListCustomerByNumber(customerNumber string) (*invoiced.Customer
 
 // ListCustomerByName lists the customer by name
 func (c *Client) ListCustomerByName(name string) (*invoiced.Customer, error) {
+	return c.ListCustomerByNameCtx(context.Background(), name)
+}
+
+func (c *Client) ListCustomerByNameCtx(ctx context.Context, name string) (_ *invoiced.Customer, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "customer.list_customer_by_name")
+	defer func() { op.End(err) }()
+
 	filter := invoiced.NewFilter()
-	err := filter.Set("name", name)
+	err = filter.Set("name", name)
 	if err != nil {
 		return nil, err
 	}
 
-	customers, err := c.ListAll(filter, nil)
+	customers, err := invoiced.CollectAll[invoiced.Customers](ctx, c.Api, invoiced.AddFilterAndSort("/customers", filter, nil))
 	if err != nil {
 		return nil, err
 	}
@@ -181,51 +218,100 @@ func (c *Client) ListCustomerByName(name string) (*invoiced.Customer, error) {
 }
 
 func (c *Client) GetBalance(id int64) (*invoiced.Balance, error) {
+	return c.GetBalanceCtx(context.Background(), id)
+}
+
+func (c *Client) GetBalanceCtx(ctx context.Context, id int64) (_ *invoiced.Balance, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "customer.get_balance")
+	defer func() { op.End(err) }()
+
 	endpoint := "/customers/" + strconv.FormatInt(id, 10) + "/balance"
 	custBalance := new(invoiced.Balance)
-	_, err := c.Api.Get(endpoint, custBalance)
+	_, err = c.Api.GetCtx(ctx, endpoint, custBalance)
 	return custBalance, err
 }
 
 func (c *Client) SendStatementEmail(id int64, request *invoiced.SendStatementEmailRequest) error {
+	return c.SendStatementEmailCtx(context.Background(), id, request)
+}
+
+func (c *Client) SendStatementEmailCtx(ctx context.Context, id int64, request *invoiced.SendStatementEmailRequest) (err error) {
+	ctx, op := c.Api.StartOperation(ctx, "customer.send_statement_email")
+	defer func() { op.End(err) }()
+
 	endpoint := "/customers/" + strconv.FormatInt(id, 10) + "/emails"
-	return c.Api.Create(endpoint, request, nil)
+	return c.Api.CreateCtx(ctx, endpoint, request, nil)
 }
 
 func (c *Client) SendStatementText(id int64, request *invoiced.SendStatementTextMessageRequest) (invoiced.TextMessages, error) {
+	return c.SendStatementTextCtx(context.Background(), id, request)
+}
+
+func (c *Client) SendStatementTextCtx(ctx context.Context, id int64, request *invoiced.SendStatementTextMessageRequest) (_ invoiced.TextMessages, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "customer.send_statement_text")
+	defer func() { op.End(err) }()
+
 	endpoint := "/customers/" + strconv.FormatInt(id, 10) + "/text_messages"
 	custStmtResp := new(invoiced.TextMessages)
-	err := c.Api.Create(endpoint, request, custStmtResp)
+	err = c.Api.CreateCtx(ctx, endpoint, request, custStmtResp)
 	return *custStmtResp, err
 }
 
 func (c *Client) SendStatementLetter(id int64, request *invoiced.SendStatementLetterRequest) (*invoiced.Letter, error) {
+	return c.SendStatementLetterCtx(context.Background(), id, request)
+}
+
+func (c *Client) SendStatementLetterCtx(ctx context.Context, id int64, request *invoiced.SendStatementLetterRequest) (_ *invoiced.Letter, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "customer.send_statement_letter")
+	defer func() { op.End(err) }()
+
 	endpoint := "/customers/" + strconv.FormatInt(id, 10) + "/letters"
 	custStmtResp := new(invoiced.Letter)
-	err := c.Api.Create(endpoint, request, custStmtResp)
+	err = c.Api.CreateCtx(ctx, endpoint, request, custStmtResp)
 	return custStmtResp, err
 }
 
 func (c *Client) CreateContact(id int64, request *invoiced.ContactRequest) (*invoiced.Contact, error) {
+	return c.CreateContactCtx(context.Background(), id, request)
+}
+
+func (c *Client) CreateContactCtx(ctx context.Context, id int64, request *invoiced.ContactRequest) (_ *invoiced.Contact, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "customer.create_contact")
+	defer func() { op.End(err) }()
+
 	endpoint := "/customers/" + strconv.FormatInt(id, 10) + "/contacts"
 	contResp := new(invoiced.Contact)
-	err := c.Api.Create(endpoint, request, contResp)
+	err = c.Api.CreateCtx(ctx, endpoint, request, contResp)
 	return contResp, err
 }
 
 func (c *Client) RetrieveContact(customerId int64, id int64) (*invoiced.Contact, error) {
+	return c.RetrieveContactCtx(context.Background(), customerId, id)
+}
+
+func (c *Client) RetrieveContactCtx(ctx context.Context, customerId int64, id int64) (_ *invoiced.Contact, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "customer.retrieve_contact")
+	defer func() { op.End(err) }()
+
 	endpoint := "/customers/" + strconv.FormatInt(customerId, 10) + "/contacts/" + strconv.FormatInt(id, 10)
 	retrievedContact := new(invoiced.Contact)
-	_, err := c.Api.Get(endpoint, retrievedContact)
+	_, err = c.Api.GetCtx(ctx, endpoint, retrievedContact)
 	return retrievedContact, err
 }
 
 func (c *Client) UpdateContact(customerId int64, id int64, request *invoiced.ContactRequest) (*invoiced.Contact, error) {
+	return c.UpdateContactCtx(context.Background(), customerId, id, request)
+}
+
+func (c *Client) UpdateContactCtx(ctx context.Context, customerId int64, id int64, request *invoiced.ContactRequest) (_ *invoiced.Contact, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "customer.update_contact")
+	defer func() { op.End(err) }()
+
 	endpoint := "/customers/" + strconv.FormatInt(customerId, 10) + "/contacts/" + strconv.FormatInt(id, 10)
 
 	contResp := new(invoiced.Contact)
 
-	err := c.Api.Update(endpoint, request, contResp)
+	err = c.Api.UpdateCtx(ctx, endpoint, request, contResp)
 	if err != nil {
 		return nil, err
 	}
@@ -234,61 +320,79 @@ func (c *Client) UpdateContact(customerId int64, id int64, request *invoiced.Con
 }
 
 func (c *Client) ListAllContacts(customerId int64) (invoiced.Contacts, error) {
-	endpoint := "/customers/" + strconv.FormatInt(customerId, 10) + "/contacts"
+	return c.ListAllContactsCtx(context.Background(), customerId)
+}
 
-	contacts := make(invoiced.Contacts, 0)
+func (c *Client) ListAllContactsCtx(ctx context.Context, customerId int64) (invoiced.Contacts, error) {
+	return c.ListAllContactsQueryCtx(ctx, customerId, nil)
+}
 
-NEXT:
-	tmpContacts := make(invoiced.Contacts, 0)
+// ListAllContactsQuery is like ListAllContacts, with the parameters of a Query.
+func (c *Client) ListAllContactsQuery(customerId int64, query *invoiced.Query) (invoiced.Contacts, error) {
+	return c.ListAllContactsQueryCtx(context.Background(), customerId, query)
+}
 
-	endpoint, err := c.Api.Get(endpoint, &tmpContacts)
+func (c *Client) ListAllContactsQueryCtx(ctx context.Context, customerId int64, query *invoiced.Query) (_ invoiced.Contacts, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "customer.list_all_contacts")
+	defer func() { op.End(err) }()
 
+	endpoint, err := query.Endpoint("/customers/" + strconv.FormatInt(customerId, 10) + "/contacts")
 	if err != nil {
 		return nil, err
 	}
 
-	contacts = append(contacts, tmpContacts...)
-
-	if endpoint != "" {
-		goto NEXT
-	}
-
-	return contacts, nil
+	return invoiced.CollectAll[invoiced.Contacts](ctx, c.Api, endpoint)
 }
 
 func (c *Client) DeleteContact(customerId int64, id int64) error {
+	return c.DeleteContactCtx(context.Background(), customerId, id)
+}
+
+func (c *Client) DeleteContactCtx(ctx context.Context, customerId int64, id int64) (err error) {
+	ctx, op := c.Api.StartOperation(ctx, "customer.delete_contact")
+	defer func() { op.End(err) }()
+
 	endpoint := "/customers/" + strconv.FormatInt(customerId, 10) + "/contacts/" + strconv.FormatInt(id, 10)
-	return c.Api.Delete(endpoint)
+	return c.Api.DeleteCtx(ctx, endpoint)
 }
 
 func (c *Client) RetrieveNotes(customerId int64) (invoiced.Notes, error) {
-	endpoint := "/customers/" + strconv.FormatInt(customerId, 10) + "/notes"
+	return c.RetrieveNotesCtx(context.Background(), customerId)
+}
 
-	notes := make(invoiced.Notes, 0)
+func (c *Client) RetrieveNotesCtx(ctx context.Context, customerId int64) (invoiced.Notes, error) {
+	return c.RetrieveNotesQueryCtx(ctx, customerId, nil)
+}
 
-NEXT:
-	tmpNotes := make(invoiced.Notes, 0)
+// RetrieveNotesQuery is like RetrieveNotes, with the parameters of a Query.
+func (c *Client) RetrieveNotesQuery(customerId int64, query *invoiced.Query) (invoiced.Notes, error) {
+	return c.RetrieveNotesQueryCtx(context.Background(), customerId, query)
+}
 
-	endpoint, err := c.Api.Get(endpoint, &tmpNotes)
+func (c *Client) RetrieveNotesQueryCtx(ctx context.Context, customerId int64, query *invoiced.Query) (_ invoiced.Notes, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "customer.retrieve_notes")
+	defer func() { op.End(err) }()
 
+	endpoint, err := query.Endpoint("/customers/" + strconv.FormatInt(customerId, 10) + "/notes")
 	if err != nil {
 		return nil, err
 	}
 
-	notes = append(notes, tmpNotes...)
-
-	if endpoint != "" {
-		goto NEXT
-	}
-
-	return notes, nil
+	return invoiced.CollectAll[invoiced.Notes](ctx, c.Api, endpoint)
 }
 
 func (c *Client) CreatePaymentSource(customerId int64, request *invoiced.PaymentSourceRequest) (*invoiced.PaymentSource, error) {
+	return c.CreatePaymentSourceCtx(context.Background(), customerId, request)
+}
+
+func (c *Client) CreatePaymentSourceCtx(ctx context.Context, customerId int64, request *invoiced.PaymentSourceRequest) (_ *invoiced.PaymentSource, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "customer.create_payment_source")
+	defer func() { op.End(err) }()
+
 	endpoint := "/customers/" + strconv.FormatInt(customerId, 10) + "/payment_sources"
 	resp := new(invoiced.PaymentSource)
 
-	err := c.Api.Create(endpoint, request, resp)
+	err = c.Api.CreateCtx(ctx, endpoint, request, resp)
 	if err != nil {
 		return nil, err
 	}
@@ -297,43 +401,66 @@ func (c *Client) CreatePaymentSource(customerId int64, request *invoiced.Payment
 }
 
 func (c *Client) ListAllPaymentSources(customerId int64) (invoiced.PaymentSources, error) {
-	endpoint := "/customers/" + strconv.FormatInt(customerId, 10) + "/payment_sources"
+	return c.ListAllPaymentSourcesCtx(context.Background(), customerId)
+}
 
-	sources := make(invoiced.PaymentSources, 0)
+func (c *Client) ListAllPaymentSourcesCtx(ctx context.Context, customerId int64) (invoiced.PaymentSources, error) {
+	return c.ListAllPaymentSourcesQueryCtx(ctx, customerId, nil)
+}
 
-NEXT:
-	tmpSources := make(invoiced.PaymentSources, 0)
+// ListAllPaymentSourcesQuery is like ListAllPaymentSources, with the parameters of a Query.
+func (c *Client) ListAllPaymentSourcesQuery(customerId int64, query *invoiced.Query) (invoiced.PaymentSources, error) {
+	return c.ListAllPaymentSourcesQueryCtx(context.Background(), customerId, query)
+}
 
-	endpoint, err := c.Api.Get(endpoint, &tmpSources)
+func (c *Client) ListAllPaymentSourcesQueryCtx(ctx context.Context, customerId int64, query *invoiced.Query) (_ invoiced.PaymentSources, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "customer.list_all_payment_sources")
+	defer func() { op.End(err) }()
 
+	endpoint, err := query.Endpoint("/customers/" + strconv.FormatInt(customerId, 10) + "/payment_sources")
 	if err != nil {
 		return nil, err
 	}
 
-	sources = append(sources, tmpSources...)
-
-	if endpoint != "" {
-		goto NEXT
-	}
-
-	return sources, nil
+	return invoiced.CollectAll[invoiced.PaymentSources](ctx, c.Api, endpoint)
 }
 
 func (c *Client) DeleteCard(customerId int64, id int64) error {
+	return c.DeleteCardCtx(context.Background(), customerId, id)
+}
+
+func (c *Client) DeleteCardCtx(ctx context.Context, customerId int64, id int64) (err error) {
+	ctx, op := c.Api.StartOperation(ctx, "customer.delete_card")
+	defer func() { op.End(err) }()
+
 	endpoint := "/customers/" + strconv.FormatInt(customerId, 10) + "/cards/" + strconv.FormatInt(id, 10)
-	return c.Api.Delete(endpoint)
+	return c.Api.DeleteCtx(ctx, endpoint)
 }
 
 func (c *Client) DeleteBankAccount(customerId int64, id int64) error {
+	return c.DeleteBankAccountCtx(context.Background(), customerId, id)
+}
+
+func (c *Client) DeleteBankAccountCtx(ctx context.Context, customerId int64, id int64) (err error) {
+	ctx, op := c.Api.StartOperation(ctx, "customer.delete_bank_account")
+	defer func() { op.End(err) }()
+
 	endpoint := "/customers/" + strconv.FormatInt(customerId, 10) + "/bank_accounts/" + strconv.FormatInt(id, 10)
-	return c.Api.Delete(endpoint)
+	return c.Api.DeleteCtx(ctx, endpoint)
 }
 
 func (c *Client) CreatePendingLineItem(customerId int64, request *invoiced.PendingLineItemRequest) (*invoiced.PendingLineItem, error) {
+	return c.CreatePendingLineItemCtx(context.Background(), customerId, request)
+}
+
+func (c *Client) CreatePendingLineItemCtx(ctx context.Context, customerId int64, request *invoiced.PendingLineItemRequest) (_ *invoiced.PendingLineItem, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "customer.create_pending_line_item")
+	defer func() { op.End(err) }()
+
 	endpoint := "/customers/" + strconv.FormatInt(customerId, 10) + "/line_items"
 	resp := new(invoiced.PendingLineItem)
 
-	err := c.Api.Create(endpoint, request, resp)
+	err = c.Api.CreateCtx(ctx, endpoint, request, resp)
 	if err != nil {
 		return nil, err
 	}
@@ -342,10 +469,17 @@ func (c *Client) CreatePendingLineItem(customerId int64, request *invoiced.Pendi
 }
 
 func (c *Client) RetrievePendingLineItem(customerId int64, id int64) (*invoiced.PendingLineItem, error) {
+	return c.RetrievePendingLineItemCtx(context.Background(), customerId, id)
+}
+
+func (c *Client) RetrievePendingLineItemCtx(ctx context.Context, customerId int64, id int64) (_ *invoiced.PendingLineItem, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "customer.retrieve_pending_line_item")
+	defer func() { op.End(err) }()
+
 	endpoint := "/customers/" + strconv.FormatInt(customerId, 10) + "/line_items/" + strconv.FormatInt(id, 10)
 	resp := new(invoiced.PendingLineItem)
 
-	_, err := c.Api.Get(endpoint, resp)
+	_, err = c.Api.GetCtx(ctx, endpoint, resp)
 	if err != nil {
 		return nil, err
 	}
@@ -354,10 +488,17 @@ func (c *Client) RetrievePendingLineItem(customerId int64, id int64) (*invoiced.
 }
 
 func (c *Client) UpdatePendingLineItem(customerId int64, id int64, request *invoiced.PendingLineItemRequest) (*invoiced.PendingLineItem, error) {
+	return c.UpdatePendingLineItemCtx(context.Background(), customerId, id, request)
+}
+
+func (c *Client) UpdatePendingLineItemCtx(ctx context.Context, customerId int64, id int64, request *invoiced.PendingLineItemRequest) (_ *invoiced.PendingLineItem, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "customer.update_pending_line_item")
+	defer func() { op.End(err) }()
+
 	endpoint := "/customers/" + strconv.FormatInt(customerId, 10) + "/line_items/" + strconv.FormatInt(id, 10)
 	resp := new(invoiced.PendingLineItem)
 
-	err := c.Api.Update(endpoint, request, resp)
+	err = c.Api.UpdateCtx(ctx, endpoint, request, resp)
 	if err != nil {
 		return nil, err
 	}
@@ -366,34 +507,43 @@ func (c *Client) UpdatePendingLineItem(customerId int64, id int64, request *invo
 }
 
 func (c *Client) ListAllPendingLineItems(customerId int64) (invoiced.PendingLineItems, error) {
-	endpoint := "/customers/" + strconv.FormatInt(customerId, 10) + "/line_items"
+	return c.ListAllPendingLineItemsCtx(context.Background(), customerId)
+}
 
-	plis := make(invoiced.PendingLineItems, 0)
+func (c *Client) ListAllPendingLineItemsCtx(ctx context.Context, customerId int64) (invoiced.PendingLineItems, error) {
+	return c.ListAllPendingLineItemsQueryCtx(ctx, customerId, nil)
+}
 
-NEXT:
-	tmpPlis := make(invoiced.PendingLineItems, 0)
+// ListAllPendingLineItemsQuery is like ListAllPendingLineItems, with the parameters of a Query.
+func (c *Client) ListAllPendingLineItemsQuery(customerId int64, query *invoiced.Query) (invoiced.PendingLineItems, error) {
+	return c.ListAllPendingLineItemsQueryCtx(context.Background(), customerId, query)
+}
 
-	endpoint, err := c.Api.Get(endpoint, &tmpPlis)
+func (c *Client) ListAllPendingLineItemsQueryCtx(ctx context.Context, customerId int64, query *invoiced.Query) (_ invoiced.PendingLineItems, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "customer.list_all_pending_line_items")
+	defer func() { op.End(err) }()
 
+	endpoint, err := query.Endpoint("/customers/" + strconv.FormatInt(customerId, 10) + "/line_items")
 	if err != nil {
 		return nil, err
 	}
 
-	plis = append(plis, tmpPlis...)
-
-	if endpoint != "" {
-		goto NEXT
-	}
-
-	return plis, nil
+	return invoiced.CollectAll[invoiced.PendingLineItems](ctx, c.Api, endpoint)
 }
 
 func (c *Client) TriggerInvoice(customerId int64) (*invoiced.Invoice, error) {
+	return c.TriggerInvoiceCtx(context.Background(), customerId)
+}
+
+func (c *Client) TriggerInvoiceCtx(ctx context.Context, customerId int64) (_ *invoiced.Invoice, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "customer.trigger_invoice")
+	defer func() { op.End(err) }()
+
 	endpoint := "/customers/" + strconv.FormatInt(customerId, 10) + "/invoices"
 
 	invoice := new(invoiced.Invoice)
 
-	err := c.Api.Create(endpoint, nil, invoice)
+	err = c.Api.CreateCtx(ctx, endpoint, nil, invoice)
 	if err != nil {
 		return nil, err
 	}
@@ -402,11 +552,18 @@ func (c *Client) TriggerInvoice(customerId int64) (*invoiced.Invoice, error) {
 }
 
 func (c *Client) ConsolidateInvoices(customerId int64) (*invoiced.Invoice, error) {
+	return c.ConsolidateInvoicesCtx(context.Background(), customerId)
+}
+
+func (c *Client) ConsolidateInvoicesCtx(ctx context.Context, customerId int64) (_ *invoiced.Invoice, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "customer.consolidate_invoices")
+	defer func() { op.End(err) }()
+
 	endpoint := "/customers/" + strconv.FormatInt(customerId, 10) + "/consolidate_invoices"
 
 	invoice := new(invoiced.Invoice)
 
-	err := c.Api.Create(endpoint, nil, invoice)
+	err = c.Api.CreateCtx(ctx, endpoint, nil, invoice)
 	if err != nil {
 		return nil, err
 	}
@@ -415,6 +572,29 @@ func (c *Client) ConsolidateInvoices(customerId int64) (*invoiced.Invoice, error
 }
 
 func (c *Client) DeletePendingLineItem(customerId int64, id int64) error {
+	return c.DeletePendingLineItemCtx(context.Background(), customerId, id)
+}
+
+func (c *Client) DeletePendingLineItemCtx(ctx context.Context, customerId int64, id int64) (err error) {
+	ctx, op := c.Api.StartOperation(ctx, "customer.delete_pending_line_item")
+	defer func() { op.End(err) }()
+
 	endpoint := "/customers/" + strconv.FormatInt(customerId, 10) + "/line_items/" + strconv.FormatInt(id, 10)
-	return c.Api.Delete(endpoint)
+	return c.Api.DeleteCtx(ctx, endpoint)
+}
+
+func (c *Client) DownloadStatementPDF(id int64, w io.Writer) (*invoiced.Download, error) {
+	return c.DownloadStatementPDFCtx(context.Background(), id, w)
+}
+
+func (c *Client) DownloadStatementPDFCtx(ctx context.Context, id int64, w io.Writer) (_ *invoiced.Download, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "customer.download_statement_pdf")
+	defer func() { op.End(err) }()
+
+	customer := new(invoiced.Customer)
+	if _, err = c.Api.GetCtx(ctx, c.resource().Endpoint(id), customer); err != nil {
+		return nil, err
+	}
+
+	return c.Api.DownloadCtx(ctx, customer.StatementPdfUrl, w)
 }
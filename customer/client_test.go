@@ -1,7 +1,9 @@
 package customer
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"github.com/Invoiced/invoiced-go/v2"
 	"reflect"
 	"strconv"
@@ -907,3 +909,58 @@ func TestCustomer_ConsolidateInvoices(t *testing.T) {
 		t.Fatal("Error: operation not completed correctly")
 	}
 }
+
+func TestCustomer_ListAllCtxCanceled(t *testing.T) {
+	key := "test api key"
+
+	mockCustomersResponse := invoiced.Customers{{Id: 1523, Name: "Mock Api"}}
+
+	server, err := invdmockserver.New(200, mockCustomersResponse, "json", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer server.Close()
+
+	client := Client{invoiced.NewMockApi(key, server)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = client.ListAllCtx(ctx, nil, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatal("Expected context.Canceled, got", err)
+	}
+
+	customers, err := client.ListAllCtx(context.Background(), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(customers, mockCustomersResponse) {
+		t.Fatal("Customers do not match up", customers)
+	}
+}
+
+func TestCustomer_ListCustomerByNumberInstrumentation(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(true, invdmockserver.ScriptedResponse{Status: 200, Body: invoiced.Customers{{Id: 1234}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	recorder := invoiced.NewMemoryRecorder()
+	client := Client{invoiced.NewMockApi("test api key", server.Server, invoiced.WithInstrumentation(recorder))}
+
+	if _, err := client.ListCustomerByNumber("CUST-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := recorder.Counter(invoiced.MetricOperations); n != 1 {
+		t.Fatal("Expected a single operation to be recorded, got", n)
+	}
+
+	if n := recorder.Counter(invoiced.MetricOperations, invoiced.Attr("operation", "list_customer_by_number")); n != 1 {
+		t.Fatal("Expected customer.list_customer_by_number to be recorded, got", n)
+	}
+}
@@ -2,6 +2,8 @@ package invoiced
 
 import (
 	"encoding/json"
+	"strings"
+	"time"
 )
 
 type CustomerRequest struct {
@@ -48,50 +50,87 @@ type CustomerRequest struct {
 	UpdatedAt              *int64                  `json:"updated_at,omitempty"`
 }
 
+func (r *CustomerRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+
+	var f fieldErrors
+	r.validate(&f)
+
+	return f.err()
+}
+
+// ValidateCreate also requires the name.
+func (r *CustomerRequest) ValidateCreate() error {
+	if r == nil {
+		return nil
+	}
+
+	var f fieldErrors
+	f.required("name", r.Name != nil && *r.Name != "")
+	r.validate(&f)
+
+	return f.err()
+}
+
+func (r *CustomerRequest) validate(f *fieldErrors) {
+	f.currency("currency", r.Currency)
+	f.country("country", r.Country)
+	f.oneOf("type", r.Type, string(CustomerTypeCompany), string(CustomerTypePerson))
+	f.nonNegative("credit_limit", r.CreditLimit)
+	f.nonNegativeInt("autopay_delay_days", r.AutoPayDelays)
+	f.id("parent_customer", r.ParentCustomer)
+
+	if r.Email != nil && *r.Email != "" && !strings.Contains(*r.Email, "@") {
+		f.add("email", "must be an email address, got %q", *r.Email)
+	}
+}
+
 type Customers []*Customer
 
 type Customer struct {
-	Address1               string                 `json:"address1"`
-	Address2               string                 `json:"address2"`
-	AttentionTo            string                 `json:"attention_to"`
-	AutoPay                bool                   `json:"autopay"`
-	AutoPayDelays          int64                  `json:"autopay_delay_days"`
-	AvalaraEntityUseCode   string                 `json:"avalara_entity_use_code"`
-	AvalaraExemptionNumber string                 `json:"avalara_exemption_number"`
-	BillToParent           bool                   `json:"bill_to_parent"`
-	Chase                  bool                   `json:"boolean"`
-	ChasingCadence         int64                  `json:"chasing_cadence"`
-	City                   string                 `json:"city"`
-	Country                string                 `json:"country"`
-	CreatedAt              int64                  `json:"created_at"`
-	CreditHold             bool                   `json:"credit_hold"`
-	CreditLimit            float64                `json:"credit_limit"`
-	Currency               string                 `json:"currency"`
-	DisabledPaymentMethods []string               `json:"disabled_payment_methods"`
-	Email                  string                 `json:"email"`
-	Id                     int64                  `json:"id"`
-	Language               string                 `json:"language"`
-	Metadata               map[string]interface{} `json:"metadata"`
-	Name                   string                 `json:"name"`
-	NextChaseStep          int64                  `json:"next_chase_step"`
-	Notes                  string                 `json:"notes"`
-	Number                 string                 `json:"number"`
-	Object                 string                 `json:"object"`
-	Owner                  int64                  `json:"owner"`
-	ParentCustomer         int64                  `json:"parent_customer"`
-	PaymentSource          *PaymentSource         `json:"payment_source"`
-	PaymentTerms           string                 `json:"payment_terms"`
-	Phone                  string                 `json:"phone"`
-	PostalCode             string                 `json:"postal_code"`
-	SignUpPage             int64                  `json:"sign_up_page"`
-	SignUpUrl              string                 `json:"sign_up_url"`
-	State                  string                 `json:"state"`
-	StatementPdfUrl        string                 `json:"statement_pdf_url"`
-	TaxId                  string                 `json:"taxid"`
-	Taxable                bool                   `json:"taxable"`
-	Taxes                  []TaxRate              `json:"taxes"`
-	Type                   string                 `json:"type"`
-	UpdatedAt              int64                  `json:"updated_at"`
+	Address1               string                           `json:"address1"`
+	Address2               string                           `json:"address2"`
+	AttentionTo            string                           `json:"attention_to"`
+	AutoPay                bool                             `json:"autopay"`
+	AutoPayDelays          int64                            `json:"autopay_delay_days"`
+	AvalaraEntityUseCode   string                           `json:"avalara_entity_use_code"`
+	AvalaraExemptionNumber string                           `json:"avalara_exemption_number"`
+	BillToParent           bool                             `json:"bill_to_parent"`
+	Chase                  bool                             `json:"boolean"`
+	ChasingCadence         int64                            `json:"chasing_cadence"`
+	City                   string                           `json:"city"`
+	Country                string                           `json:"country"`
+	CreatedAt              int64                            `json:"created_at"`
+	CreditHold             bool                             `json:"credit_hold"`
+	CreditLimit            float64                          `json:"credit_limit"`
+	Currency               string                           `json:"currency"`
+	DisabledPaymentMethods []string                         `json:"disabled_payment_methods"`
+	Email                  string                           `json:"email"`
+	Id                     int64                            `json:"id"`
+	Language               string                           `json:"language"`
+	Metadata               map[string]interface{}           `json:"metadata"`
+	Name                   string                           `json:"name"`
+	NextChaseStep          int64                            `json:"next_chase_step"`
+	Notes                  string                           `json:"notes"`
+	Number                 string                           `json:"number"`
+	Object                 string                           `json:"object"`
+	Owner                  Expandable[User, int64]          `json:"owner"`
+	ParentCustomer         Expandable[Customer, int64]      `json:"parent_customer"`
+	PaymentSource          Expandable[PaymentSource, int64] `json:"payment_source"`
+	PaymentTerms           PaymentTerms                     `json:"payment_terms"`
+	Phone                  string                           `json:"phone"`
+	PostalCode             string                           `json:"postal_code"`
+	SignUpPage             int64                            `json:"sign_up_page"`
+	SignUpUrl              string                           `json:"sign_up_url"`
+	State                  string                           `json:"state"`
+	StatementPdfUrl        string                           `json:"statement_pdf_url"`
+	TaxId                  string                           `json:"taxid"`
+	Taxable                bool                             `json:"taxable"`
+	Taxes                  []TaxRate                        `json:"taxes"`
+	Type                   CustomerType                     `json:"type"`
+	UpdatedAt              int64                            `json:"updated_at"`
 }
 
 func (c *Customer) String() string {
@@ -99,3 +138,11 @@ func (c *Customer) String() string {
 
 	return string(b)
 }
+
+func (c *Customer) CreatedAtTime() time.Time {
+	return Time(c.CreatedAt)
+}
+
+func (c *Customer) UpdatedAtTime() time.Time {
+	return Time(c.UpdatedAt)
+}
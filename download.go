@@ -0,0 +1,99 @@
+package invoiced
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// ErrNoDownloadUrl is returned when asked to download a document whose URL
+// was not provided by the API, such as the PDF of a draft.
+var ErrNoDownloadUrl = errors.New("invoiced: no download URL")
+
+// Download describes a downloaded document.
+type Download struct {
+	ContentType string
+	Size        int64
+
+	// SHA256 is the hex encoded SHA-256 checksum of the document.
+	SHA256 string
+}
+
+func (c *Api) Download(url string, w io.Writer) (*Download, error) {
+	return c.DownloadCtx(context.Background(), url, w)
+}
+
+// DownloadCtx streams the document at url, such as an invoice PdfUrl, to w
+// and reports its checksum. url may also be an endpoint of the API. The
+// request goes through the same transport, retries, rate limit and
+// middleware as API calls, but the API key is only sent to the API.
+func (c *Api) DownloadCtx(ctx context.Context, url string, w io.Writer) (*Download, error) {
+	if url == "" {
+		return nil, ErrNoDownloadUrl
+	}
+
+	resp, err := c.get(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeBody(resp)
+
+	if err := checkStatusForError(resp); err != nil {
+		return nil, err
+	}
+
+	checksum := sha256.New()
+
+	size, err := io.Copy(io.MultiWriter(w, checksum), resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Download{
+		ContentType: resp.Header.Get("Content-Type"),
+		Size:        size,
+		SHA256:      hexSum(checksum),
+	}, nil
+}
+
+func (c *Api) DownloadToFile(url string, path string) (*Download, error) {
+	return c.DownloadToFileCtx(context.Background(), url, path)
+}
+
+// DownloadToFileCtx streams the document at url to the file at path. The
+// document is written to a temporary file next to path which replaces it
+// once complete, so path never holds a partial download.
+func (c *Api) DownloadToFileCtx(ctx context.Context, url string, path string) (*Download, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	download, err := c.DownloadCtx(ctx, url, tmp)
+	if err != nil {
+		tmp.Close()
+		return nil, err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, err
+	}
+
+	return download, nil
+}
+
+func hexSum(h hash.Hash) string {
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,95 @@
+package invoiced
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Invoiced/invoiced-go/v2/invdmockserver"
+)
+
+var testPDF = []byte("%PDF-1.4 test document")
+
+func newPDFServer(authorization *string) *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*authorization = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/pdf")
+		_, _ = w.Write(testPDF)
+	}))
+}
+
+func TestDownload(t *testing.T) {
+	var authorization string
+	pdfServer := newPDFServer(&authorization)
+	defer pdfServer.Close()
+
+	apiServer, err := invdmockserver.NewScriptedServer(true, invdmockserver.ScriptedResponse{Status: 200, Body: map[string]string{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer apiServer.Close()
+
+	client := NewMockApi("secret", apiServer.Server)
+
+	var buf bytes.Buffer
+	download, err := client.Download(pdfServer.URL+"/invoices/abc/pdf", &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sum := sha256.Sum256(testPDF)
+	if !bytes.Equal(buf.Bytes(), testPDF) || download.Size != int64(len(testPDF)) || download.SHA256 != hex.EncodeToString(sum[:]) || download.ContentType != "application/pdf" {
+		t.Fatal("Unexpected download", download)
+	}
+
+	if authorization != "" {
+		t.Fatal("The API key was sent to another host")
+	}
+
+	if _, err := client.Download("/invoices/1234", &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if apiServer.Requests()[0].Header.Get("Authorization") == "" {
+		t.Fatal("The API key was not sent to the API")
+	}
+}
+
+func TestDownloadToFile(t *testing.T) {
+	var authorization string
+	pdfServer := newPDFServer(&authorization)
+	defer pdfServer.Close()
+
+	client := NewMockApi("secret", pdfServer)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "invoice.pdf")
+
+	if _, err := client.DownloadToFile(pdfServer.URL+"/pdf", path); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil || !bytes.Equal(b, testPDF) {
+		t.Fatal("Unexpected file", string(b), err)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Fatal("Expected the temporary file to be gone", entries)
+	}
+}
+
+func TestDownloadWithoutUrl(t *testing.T) {
+	client := New("secret", false)
+
+	if _, err := client.Download("", new(bytes.Buffer)); !errors.Is(err, ErrNoDownloadUrl) {
+		t.Fatal("Expected ErrNoDownloadUrl, got", err)
+	}
+}
@@ -0,0 +1,315 @@
+package invoiced
+
+import (
+	"strconv"
+	"strings"
+)
+
+// The string-valued fields of the models have named types with a constant
+// for every documented value. Values unknown to this version of the library,
+// e.g. added to the API later, still decode and encode unchanged; IsValid
+// reports whether a value is one of the documented ones.
+
+type InvoiceStatus string
+
+const (
+	InvoiceStatusDraft   InvoiceStatus = "draft"
+	InvoiceStatusNotSent InvoiceStatus = "not_sent"
+	InvoiceStatusSent    InvoiceStatus = "sent"
+	InvoiceStatusViewed  InvoiceStatus = "viewed"
+	InvoiceStatusPastDue InvoiceStatus = "past_due"
+	InvoiceStatusPending InvoiceStatus = "pending"
+	InvoiceStatusPaid    InvoiceStatus = "paid"
+	InvoiceStatusVoided  InvoiceStatus = "voided"
+)
+
+func (s InvoiceStatus) IsValid() bool {
+	switch s {
+	case InvoiceStatusDraft, InvoiceStatusNotSent, InvoiceStatusSent, InvoiceStatusViewed, InvoiceStatusPastDue,
+		InvoiceStatusPending, InvoiceStatusPaid, InvoiceStatusVoided:
+		return true
+	}
+
+	return false
+}
+
+type CreditNoteStatus string
+
+const (
+	CreditNoteStatusDraft  CreditNoteStatus = "draft"
+	CreditNoteStatusOpen   CreditNoteStatus = "open"
+	CreditNoteStatusPaid   CreditNoteStatus = "paid"
+	CreditNoteStatusClosed CreditNoteStatus = "closed"
+	CreditNoteStatusVoided CreditNoteStatus = "voided"
+)
+
+func (s CreditNoteStatus) IsValid() bool {
+	switch s {
+	case CreditNoteStatusDraft, CreditNoteStatusOpen, CreditNoteStatusPaid, CreditNoteStatusClosed, CreditNoteStatusVoided:
+		return true
+	}
+
+	return false
+}
+
+type EstimateStatus string
+
+const (
+	EstimateStatusDraft    EstimateStatus = "draft"
+	EstimateStatusNotSent  EstimateStatus = "not_sent"
+	EstimateStatusSent     EstimateStatus = "sent"
+	EstimateStatusViewed   EstimateStatus = "viewed"
+	EstimateStatusApproved EstimateStatus = "approved"
+	EstimateStatusDeclined EstimateStatus = "declined"
+	EstimateStatusInvoiced EstimateStatus = "invoiced"
+	EstimateStatusVoided   EstimateStatus = "voided"
+)
+
+func (s EstimateStatus) IsValid() bool {
+	switch s {
+	case EstimateStatusDraft, EstimateStatusNotSent, EstimateStatusSent, EstimateStatusViewed,
+		EstimateStatusApproved, EstimateStatusDeclined, EstimateStatusInvoiced, EstimateStatusVoided:
+		return true
+	}
+
+	return false
+}
+
+type CustomerType string
+
+const (
+	CustomerTypeCompany CustomerType = "company"
+	CustomerTypePerson  CustomerType = "person"
+)
+
+func (t CustomerType) IsValid() bool {
+	return t == CustomerTypeCompany || t == CustomerTypePerson
+}
+
+// PaymentTerms are either "Due on Receipt", "AutoPay" or a number of days
+// in the form "NET 30". NetTerms builds the latter.
+type PaymentTerms string
+
+const (
+	PaymentTermsDueOnReceipt PaymentTerms = "Due on Receipt"
+	PaymentTermsAutoPay      PaymentTerms = "AutoPay"
+	PaymentTermsNet7         PaymentTerms = "NET 7"
+	PaymentTermsNet14        PaymentTerms = "NET 14"
+	PaymentTermsNet15        PaymentTerms = "NET 15"
+	PaymentTermsNet30        PaymentTerms = "NET 30"
+	PaymentTermsNet45        PaymentTerms = "NET 45"
+	PaymentTermsNet60        PaymentTerms = "NET 60"
+	PaymentTermsNet90        PaymentTerms = "NET 90"
+)
+
+func NetTerms(days int) PaymentTerms {
+	return PaymentTerms("NET " + strconv.Itoa(days))
+}
+
+// NetDays returns the number of days of "NET" terms.
+func (t PaymentTerms) NetDays() (int, bool) {
+	days, ok := strings.CutPrefix(string(t), "NET ")
+	if !ok {
+		return 0, false
+	}
+
+	n, err := strconv.Atoi(days)
+	if err != nil || n < 0 || strconv.Itoa(n) != days {
+		return 0, false
+	}
+
+	return n, true
+}
+
+func (t PaymentTerms) IsValid() bool {
+	if t == PaymentTermsDueOnReceipt || t == PaymentTermsAutoPay {
+		return true
+	}
+
+	_, ok := t.NetDays()
+
+	return ok
+}
+
+type PaymentMethod string
+
+const (
+	PaymentMethodCreditCard   PaymentMethod = "credit_card"
+	PaymentMethodAch          PaymentMethod = "ach"
+	PaymentMethodDirectDebit  PaymentMethod = "direct_debit"
+	PaymentMethodEft          PaymentMethod = "eft"
+	PaymentMethodPaypal       PaymentMethod = "paypal"
+	PaymentMethodWireTransfer PaymentMethod = "wire_transfer"
+	PaymentMethodCheck        PaymentMethod = "check"
+	PaymentMethodCash         PaymentMethod = "cash"
+	PaymentMethodBalance      PaymentMethod = "balance"
+	PaymentMethodOther        PaymentMethod = "other"
+)
+
+func (m PaymentMethod) IsValid() bool {
+	switch m {
+	case PaymentMethodCreditCard, PaymentMethodAch, PaymentMethodDirectDebit, PaymentMethodEft, PaymentMethodPaypal,
+		PaymentMethodWireTransfer, PaymentMethodCheck, PaymentMethodCash, PaymentMethodBalance, PaymentMethodOther:
+		return true
+	}
+
+	return false
+}
+
+type Interval string
+
+const (
+	IntervalDay   Interval = "day"
+	IntervalWeek  Interval = "week"
+	IntervalMonth Interval = "month"
+	IntervalYear  Interval = "year"
+)
+
+func (i Interval) IsValid() bool {
+	switch i {
+	case IntervalDay, IntervalWeek, IntervalMonth, IntervalYear:
+		return true
+	}
+
+	return false
+}
+
+type PricingMode string
+
+const (
+	PricingModePerUnit PricingMode = "per_unit"
+	PricingModeVolume  PricingMode = "volume"
+	PricingModeTiered  PricingMode = "tiered"
+	PricingModeCustom  PricingMode = "custom"
+)
+
+func (m PricingMode) IsValid() bool {
+	switch m {
+	case PricingModePerUnit, PricingModeVolume, PricingModeTiered, PricingModeCustom:
+		return true
+	}
+
+	return false
+}
+
+type SubscriptionStatus string
+
+const (
+	SubscriptionStatusNotStarted     SubscriptionStatus = "not_started"
+	SubscriptionStatusActive         SubscriptionStatus = "active"
+	SubscriptionStatusPastDue        SubscriptionStatus = "past_due"
+	SubscriptionStatusPaused         SubscriptionStatus = "paused"
+	SubscriptionStatusPendingRenewal SubscriptionStatus = "pending_renewal"
+	SubscriptionStatusFinished       SubscriptionStatus = "finished"
+	SubscriptionStatusCanceled       SubscriptionStatus = "canceled"
+)
+
+func (s SubscriptionStatus) IsValid() bool {
+	switch s {
+	case SubscriptionStatusNotStarted, SubscriptionStatusActive, SubscriptionStatusPastDue, SubscriptionStatusPaused,
+		SubscriptionStatusPendingRenewal, SubscriptionStatusFinished, SubscriptionStatusCanceled:
+		return true
+	}
+
+	return false
+}
+
+type ChasingAction string
+
+const (
+	ChasingActionEmail    ChasingAction = "email"
+	ChasingActionSms      ChasingAction = "sms"
+	ChasingActionPhone    ChasingAction = "phone"
+	ChasingActionMail     ChasingAction = "mail"
+	ChasingActionEscalate ChasingAction = "escalate"
+)
+
+func (a ChasingAction) IsValid() bool {
+	switch a {
+	case ChasingActionEmail, ChasingActionSms, ChasingActionPhone, ChasingActionMail, ChasingActionEscalate:
+		return true
+	}
+
+	return false
+}
+
+type EventType string
+
+const (
+	EventContactCreated         EventType = "contact.created"
+	EventContactUpdated         EventType = "contact.updated"
+	EventContactDeleted         EventType = "contact.deleted"
+	EventCreditNoteCreated      EventType = "credit_note.created"
+	EventCreditNoteUpdated      EventType = "credit_note.updated"
+	EventCreditNoteDeleted      EventType = "credit_note.deleted"
+	EventCreditNoteViewed       EventType = "credit_note.viewed"
+	EventCustomerCreated        EventType = "customer.created"
+	EventCustomerUpdated        EventType = "customer.updated"
+	EventCustomerDeleted        EventType = "customer.deleted"
+	EventCustomerMerged         EventType = "customer.merged"
+	EventEstimateCreated        EventType = "estimate.created"
+	EventEstimateUpdated        EventType = "estimate.updated"
+	EventEstimateDeleted        EventType = "estimate.deleted"
+	EventEstimateViewed         EventType = "estimate.viewed"
+	EventEstimateApproved       EventType = "estimate.approved"
+	EventEstimateCommented      EventType = "estimate.commented"
+	EventInvoiceCreated         EventType = "invoice.created"
+	EventInvoiceUpdated         EventType = "invoice.updated"
+	EventInvoiceDeleted         EventType = "invoice.deleted"
+	EventInvoiceViewed          EventType = "invoice.viewed"
+	EventInvoicePaid            EventType = "invoice.paid"
+	EventInvoicePaymentExpected EventType = "invoice.payment_expected"
+	EventInvoiceCommented       EventType = "invoice.commented"
+	EventLineItemCreated        EventType = "line_item.created"
+	EventLineItemUpdated        EventType = "line_item.updated"
+	EventLineItemDeleted        EventType = "line_item.deleted"
+	EventNoteCreated            EventType = "note.created"
+	EventNoteUpdated            EventType = "note.updated"
+	EventNoteDeleted            EventType = "note.deleted"
+	EventPaymentCreated         EventType = "payment.created"
+	EventPaymentUpdated         EventType = "payment.updated"
+	EventPaymentDeleted         EventType = "payment.deleted"
+	EventPaymentPlanCreated     EventType = "payment_plan.created"
+	EventPaymentPlanUpdated     EventType = "payment_plan.updated"
+	EventPaymentPlanDeleted     EventType = "payment_plan.deleted"
+	EventPaymentSourceCreated   EventType = "payment_source.created"
+	EventPaymentSourceUpdated   EventType = "payment_source.updated"
+	EventPaymentSourceDeleted   EventType = "payment_source.deleted"
+	EventRefundCreated          EventType = "refund.created"
+	EventSubscriptionCreated    EventType = "subscription.created"
+	EventSubscriptionUpdated    EventType = "subscription.updated"
+	EventSubscriptionDeleted    EventType = "subscription.deleted"
+	EventTaskCreated            EventType = "task.created"
+	EventTaskUpdated            EventType = "task.updated"
+	EventTaskDeleted            EventType = "task.deleted"
+	EventTaskCompleted          EventType = "task.completed"
+)
+
+var eventTypes = map[EventType]bool{
+	EventContactCreated: true, EventContactUpdated: true, EventContactDeleted: true,
+	EventCreditNoteCreated: true, EventCreditNoteUpdated: true, EventCreditNoteDeleted: true, EventCreditNoteViewed: true,
+	EventCustomerCreated: true, EventCustomerUpdated: true, EventCustomerDeleted: true, EventCustomerMerged: true,
+	EventEstimateCreated: true, EventEstimateUpdated: true, EventEstimateDeleted: true, EventEstimateViewed: true,
+	EventEstimateApproved: true, EventEstimateCommented: true,
+	EventInvoiceCreated: true, EventInvoiceUpdated: true, EventInvoiceDeleted: true, EventInvoiceViewed: true,
+	EventInvoicePaid: true, EventInvoicePaymentExpected: true, EventInvoiceCommented: true,
+	EventLineItemCreated: true, EventLineItemUpdated: true, EventLineItemDeleted: true,
+	EventNoteCreated: true, EventNoteUpdated: true, EventNoteDeleted: true,
+	EventPaymentCreated: true, EventPaymentUpdated: true, EventPaymentDeleted: true,
+	EventPaymentPlanCreated: true, EventPaymentPlanUpdated: true, EventPaymentPlanDeleted: true,
+	EventPaymentSourceCreated: true, EventPaymentSourceUpdated: true, EventPaymentSourceDeleted: true,
+	EventRefundCreated:       true,
+	EventSubscriptionCreated: true, EventSubscriptionUpdated: true, EventSubscriptionDeleted: true,
+	EventTaskCreated: true, EventTaskUpdated: true, EventTaskDeleted: true, EventTaskCompleted: true,
+}
+
+func (t EventType) IsValid() bool {
+	return eventTypes[t]
+}
+
+// Object returns the type of object the event is about, e.g. "invoice" for
+// "invoice.paid".
+func (t EventType) Object() string {
+	object, _, _ := strings.Cut(string(t), ".")
+	return object
+}
@@ -0,0 +1,77 @@
+package invoiced
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEnumsIsValid(t *testing.T) {
+	if !InvoiceStatusPastDue.IsValid() || InvoiceStatus("past due").IsValid() {
+		t.Fatal("Unexpected InvoiceStatus.IsValid")
+	}
+
+	if !CustomerTypePerson.IsValid() || CustomerType("").IsValid() {
+		t.Fatal("Unexpected CustomerType.IsValid")
+	}
+
+	if !PaymentMethodAch.IsValid() || !IntervalMonth.IsValid() || !PricingModeTiered.IsValid() ||
+		!SubscriptionStatusCanceled.IsValid() || !ChasingActionEscalate.IsValid() || !EventInvoicePaid.IsValid() ||
+		!CreditNoteStatusOpen.IsValid() || !EstimateStatusApproved.IsValid() {
+		t.Fatal("Expected documented values to be valid")
+	}
+
+	if EventType("invoice.exploded").IsValid() || EventInvoicePaymentExpected.Object() != "invoice" {
+		t.Fatal("Unexpected EventType")
+	}
+}
+
+func TestPaymentTerms(t *testing.T) {
+	valid := []PaymentTerms{PaymentTermsDueOnReceipt, PaymentTermsAutoPay, PaymentTermsNet30, NetTerms(21), "NET 0"}
+	for _, terms := range valid {
+		if !terms.IsValid() {
+			t.Fatal("Expected valid terms", terms)
+		}
+	}
+
+	invalid := []PaymentTerms{"", "NET15", "NET -1", "NET 030", "net 30"}
+	for _, terms := range invalid {
+		if terms.IsValid() {
+			t.Fatal("Expected invalid terms", terms)
+		}
+	}
+
+	if days, ok := PaymentTermsNet45.NetDays(); !ok || days != 45 {
+		t.Fatal("Unexpected NetDays", days, ok)
+	}
+}
+
+func TestEnumsJSONUnknownValues(t *testing.T) {
+	var subscription Subscription
+	if err := json.Unmarshal([]byte(`{"status":"hibernating","customer":1,"plan":"gold"}`), &subscription); err != nil {
+		t.Fatal(err)
+	}
+
+	if subscription.Status != "hibernating" || subscription.Status.IsValid() {
+		t.Fatal("Expected the unknown status to be kept", subscription.Status)
+	}
+
+	var event Event
+	if err := json.Unmarshal([]byte(`{"id":1,"type":"invoice.paid"}`), &event); err != nil {
+		t.Fatal(err)
+	}
+
+	if event.Type != EventInvoicePaid {
+		t.Fatal("Unexpected event type", event.Type)
+	}
+
+	b, err := json.Marshal(&Plan{Interval: "fortnight", PricingMode: PricingModeVolume})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var plan map[string]interface{}
+	_ = json.Unmarshal(b, &plan)
+	if plan["interval"] != "fortnight" || plan["pricing_mode"] != "volume" {
+		t.Fatal("Unexpected JSON", string(b))
+	}
+}
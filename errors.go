@@ -2,16 +2,35 @@ package invoiced
 
 import (
 	"encoding/json"
+	"errors"
+	"net/http"
 )
 
+// Sentinel errors matched by errors.Is against an *APIError, e.g.
+// errors.Is(err, invoiced.ErrNotFound).
+var (
+	ErrNotFound       = errors.New("invoiced: not found")
+	ErrRateLimited    = errors.New("invoiced: rate limited")
+	ErrAuthentication = errors.New("invoiced: authentication failed")
+	ErrInvalidRequest = errors.New("invoiced: invalid request")
+)
+
+// APIError is returned for every response with a 4xx or 5xx status. Use
+// errors.As to access it, or the Is* helpers to classify it.
 type APIError struct {
 	Type    string `json:"type"`
 	Message string `json:"message"`
 	Param   string `json:"param"`
+
+	// StatusCode, RequestId and Header describe the HTTP response that
+	// carried the error. They are not part of the JSON error body.
+	StatusCode int         `json:"-"`
+	RequestId  string      `json:"-"`
+	Header     http.Header `json:"-"`
 }
 
 func NewAPIError(typeE, message, param string) *APIError {
-	err := &APIError{typeE, message, param}
+	err := &APIError{Type: typeE, Message: message, Param: param}
 	return err
 }
 
@@ -23,3 +42,54 @@ func (a *APIError) Error() string {
 
 	return string(b)
 }
+
+// Is reports whether the error belongs to the class of the given sentinel.
+// An error belongs to at most one class.
+func (a *APIError) Is(target error) bool {
+	class := a.class()
+
+	return class != nil && class == target
+}
+
+// class returns the sentinel of the class of the error, or nil. The status
+// code decides the class, and the type only when the status code does not,
+// since the API also reports e.g. a missing object as an invalid_request.
+func (a *APIError) class() error {
+	switch a.StatusCode {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	case http.StatusUnauthorized:
+		return ErrAuthentication
+	case http.StatusBadRequest:
+		return ErrInvalidRequest
+	}
+
+	switch a.Type {
+	case "rate_limit_error":
+		return ErrRateLimited
+	case "authentication_error":
+		return ErrAuthentication
+	case "invalid_request":
+		return ErrInvalidRequest
+	}
+
+	return nil
+}
+
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
+
+func IsAuthentication(err error) bool {
+	return errors.Is(err, ErrAuthentication)
+}
+
+func IsInvalidRequest(err error) bool {
+	return errors.Is(err, ErrInvalidRequest)
+}
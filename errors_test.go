@@ -1,6 +1,11 @@
 package invoiced
 
-import "testing"
+import (
+	"errors"
+	"testing"
+
+	"github.com/Invoiced/invoiced-go/v2/invdmockserver"
+)
 
 func TestNewAPIError(t *testing.T) {
 	error := NewAPIError("", "", "")
@@ -9,3 +14,74 @@ func TestNewAPIError(t *testing.T) {
 		t.Fatal("Error did not initialize")
 	}
 }
+
+func TestAPIErrorClassifiers(t *testing.T) {
+	cases := []struct {
+		status   int
+		typeE    string
+		sentinel error
+		check    func(error) bool
+	}{
+		{404, "invalid_request", ErrNotFound, IsNotFound},
+		{429, "rate_limit_error", ErrRateLimited, IsRateLimited},
+		{401, "authentication_error", ErrAuthentication, IsAuthentication},
+		{400, "invalid_request", ErrInvalidRequest, IsInvalidRequest},
+	}
+
+	for _, c := range cases {
+		server, err := invdmockserver.NewScriptedServer(true, invdmockserver.ScriptedResponse{
+			Status:  c.status,
+			Headers: map[string]string{"X-Request-Id": "req_123"},
+			Body:    NewAPIError(c.typeE, "something went wrong", "name"),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		client := NewMockApi("whatever", server.Server)
+		_, err = client.Get("/customers/1234", new(Customer))
+		server.Close()
+
+		if !c.check(err) || !errors.Is(err, c.sentinel) {
+			t.Fatal("Error with status", c.status, "was not classified correctly", err)
+		}
+
+		apiError := new(APIError)
+		if !errors.As(err, &apiError) {
+			t.Fatal("Error should be an *APIError")
+		}
+
+		if apiError.StatusCode != c.status || apiError.Type != c.typeE || apiError.Message != "something went wrong" || apiError.Param != "name" {
+			t.Fatal("Error fields were not populated", apiError)
+		}
+
+		if apiError.RequestId != "req_123" || apiError.Header.Get("Content-Type") != "application/json" {
+			t.Fatal("Response details were not populated", apiError.RequestId, apiError.Header)
+		}
+	}
+}
+
+func TestAPIErrorDoesNotMatchOtherClasses(t *testing.T) {
+	err := error(&APIError{Type: "api_error", StatusCode: 500})
+
+	if IsNotFound(err) || IsRateLimited(err) || IsAuthentication(err) || IsInvalidRequest(err) {
+		t.Fatal("A server error should not match any client error class")
+	}
+
+	notFound := error(&APIError{Type: "invalid_request", StatusCode: 404})
+	if !IsNotFound(notFound) || IsInvalidRequest(notFound) {
+		t.Fatal("A missing object should only be classified as not found")
+	}
+
+	if IsNotFound(errors.New("not found")) {
+		t.Fatal("Only API errors should be classified")
+	}
+}
+
+func TestAPIErrorStringExcludesResponseDetails(t *testing.T) {
+	err := &APIError{Type: "invalid_request", Message: "Name missing", Param: "name", StatusCode: 400, RequestId: "req_123"}
+
+	if err.Error() != `{"type":"invalid_request","message":"Name missing","param":"name"}` {
+		t.Fatal("Unexpected error string", err.Error())
+	}
+}
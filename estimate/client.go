@@ -1,7 +1,9 @@
 package estimate
 
 import (
+	"context"
 	"github.com/Invoiced/invoiced-go/v2"
+	"io"
 	"strconv"
 )
 
@@ -9,105 +11,173 @@ type Client struct {
 	*invoiced.Api
 }
 
+func (c *Client) resource() invoiced.Resource[invoiced.Estimate, invoiced.EstimateRequest, int64] {
+	return invoiced.NewResource[invoiced.Estimate, invoiced.EstimateRequest, int64](c.Api, "estimate", "/estimates")
+}
+
 func (c *Client) Create(request *invoiced.EstimateRequest) (*invoiced.Estimate, error) {
-	resp := new(invoiced.Estimate)
-	err := c.Api.Create("/estimates", request, resp)
-	return resp, err
+	return c.CreateCtx(context.Background(), request)
+}
+
+func (c *Client) CreateCtx(ctx context.Context, request *invoiced.EstimateRequest) (*invoiced.Estimate, error) {
+	return c.resource().CreateCtx(ctx, request)
 }
 
 func (c *Client) Retrieve(id int64) (*invoiced.Estimate, error) {
-	resp := new(invoiced.Estimate)
-	_, err := c.Api.Get("/estimates/"+strconv.FormatInt(id, 10), resp)
-	return resp, err
+	return c.RetrieveCtx(context.Background(), id)
+}
+
+func (c *Client) RetrieveCtx(ctx context.Context, id int64) (*invoiced.Estimate, error) {
+	return c.resource().RetrieveCtx(ctx, id)
 }
 
 func (c *Client) Update(id int64, request *invoiced.EstimateRequest) (*invoiced.Estimate, error) {
-	resp := new(invoiced.Estimate)
-	err := c.Api.Update("/estimates/"+strconv.FormatInt(id, 10), request, resp)
-	return resp, err
+	return c.UpdateCtx(context.Background(), id, request)
+}
+
+func (c *Client) UpdateCtx(ctx context.Context, id int64, request *invoiced.EstimateRequest) (*invoiced.Estimate, error) {
+	return c.resource().UpdateCtx(ctx, id, request)
 }
 
 func (c *Client) Void(id int64) (*invoiced.Estimate, error) {
+	return c.VoidCtx(context.Background(), id)
+}
+
+func (c *Client) VoidCtx(ctx context.Context, id int64) (_ *invoiced.Estimate, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "estimate.void")
+	defer func() { op.End(err) }()
+
 	resp := new(invoiced.Estimate)
-	err := c.Api.PostWithoutData("/estimates/"+strconv.FormatInt(id, 10)+"/void", resp)
+	err = c.Api.PostWithoutDataCtx(ctx, "/estimates/"+strconv.FormatInt(id, 10)+"/void", resp)
 	return resp, err
 }
 
 func (c *Client) Delete(id int64) error {
-	return c.Api.Delete("/estimates/" + strconv.FormatInt(id, 10))
+	return c.DeleteCtx(context.Background(), id)
+}
+
+func (c *Client) DeleteCtx(ctx context.Context, id int64) error {
+	return c.resource().DeleteCtx(ctx, id)
 }
 
 func (c *Client) Count() (int64, error) {
-	return c.Api.Count("/estimates")
+	return c.CountCtx(context.Background())
 }
 
-func (c *Client) ListAll(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Estimates, error) {
-	endpoint := invoiced.AddFilterAndSort("/estimates", filter, sort)
+func (c *Client) CountCtx(ctx context.Context) (int64, error) {
+	return c.resource().CountCtx(ctx)
+}
 
-	estimates := make(invoiced.Estimates, 0)
+func (c *Client) ListAll(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Estimates, error) {
+	return c.ListAllCtx(context.Background(), filter, sort)
+}
 
-NEXT:
-	tmpInvoices := make(invoiced.Estimates, 0)
+func (c *Client) ListAllCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Estimates, error) {
+	return c.resource().ListAllCtx(ctx, filter, sort)
+}
 
-	endpoint, err := c.Api.Get(endpoint, &tmpInvoices)
+func (c *Client) Iterate(filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.Estimate] {
+	return c.IterateCtx(context.Background(), filter, sort)
+}
 
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) IterateCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.Estimate] {
+	return c.resource().IterateCtx(ctx, filter, sort)
+}
 
-	estimates = append(estimates, tmpInvoices...)
+func (c *Client) List(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Estimates, string, error) {
+	return c.ListCtx(context.Background(), filter, sort)
+}
 
-	if endpoint != "" {
-		goto NEXT
-	}
+func (c *Client) ListCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Estimates, string, error) {
+	return c.resource().ListCtx(ctx, filter, sort)
+}
 
-	return estimates, nil
+func (c *Client) ListQuery(query *invoiced.Query) (invoiced.Estimates, string, error) {
+	return c.ListQueryCtx(context.Background(), query)
 }
 
-func (c *Client) List(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Estimates, string, error) {
-	endpoint := invoiced.AddFilterAndSort("/estimates", filter, sort)
+func (c *Client) ListQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.Estimates, string, error) {
+	return c.resource().ListQueryCtx(ctx, query)
+}
 
-	estimates := make(invoiced.Estimates, 0)
+func (c *Client) ListAllQuery(query *invoiced.Query) (invoiced.Estimates, error) {
+	return c.ListAllQueryCtx(context.Background(), query)
+}
 
-	nextEndpoint, err := c.Api.Get(endpoint, &estimates)
+func (c *Client) ListAllQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.Estimates, error) {
+	return c.resource().ListAllQueryCtx(ctx, query)
+}
 
-	if err != nil {
-		return nil, "", err
-	}
+func (c *Client) IterateQuery(query *invoiced.Query) *invoiced.Iter[*invoiced.Estimate] {
+	return c.IterateQueryCtx(context.Background(), query)
+}
 
-	return estimates, nextEndpoint, nil
+func (c *Client) IterateQueryCtx(ctx context.Context, query *invoiced.Query) *invoiced.Iter[*invoiced.Estimate] {
+	return c.resource().IterateQueryCtx(ctx, query)
 }
 
 func (c *Client) GenerateInvoice(id int64) (*invoiced.Invoice, error) {
+	return c.GenerateInvoiceCtx(context.Background(), id)
+}
+
+func (c *Client) GenerateInvoiceCtx(ctx context.Context, id int64) (_ *invoiced.Invoice, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "estimate.generate_invoice")
+	defer func() { op.End(err) }()
+
 	endpoint := "/estimates/" + strconv.FormatInt(id, 10) + "/invoice"
 	resp := new(invoiced.Invoice)
-	err := c.Api.PostWithoutData(endpoint, resp)
+	err = c.Api.PostWithoutDataCtx(ctx, endpoint, resp)
 	return resp, err
 }
 
 func (c *Client) SendEmail(id int64, request *invoiced.SendEmailRequest) error {
-	return c.Api.Create("/estimates/" + strconv.FormatInt(id, 10) + "/emails", request, nil)
+	return c.SendEmailCtx(context.Background(), id, request)
+}
+
+func (c *Client) SendEmailCtx(ctx context.Context, id int64, request *invoiced.SendEmailRequest) (err error) {
+	ctx, op := c.Api.StartOperation(ctx, "estimate.send_email")
+	defer func() { op.End(err) }()
+
+	return c.Api.CreateCtx(ctx, "/estimates/"+strconv.FormatInt(id, 10)+"/emails", request, nil)
 }
 
 func (c *Client) ListAttachments(id int64) (invoiced.Files, error) {
-	endpoint := "/estimates/" + strconv.FormatInt(id, 10) + "/attachments"
+	return c.ListAttachmentsCtx(context.Background(), id)
+}
 
-	files := make(invoiced.Files, 0)
+func (c *Client) ListAttachmentsCtx(ctx context.Context, id int64) (invoiced.Files, error) {
+	return c.ListAttachmentsQueryCtx(ctx, id, nil)
+}
 
-NEXT:
-	tempFiles := make(invoiced.Files, 0)
+// ListAttachmentsQuery is like ListAttachments, with the parameters of a Query.
+func (c *Client) ListAttachmentsQuery(id int64, query *invoiced.Query) (invoiced.Files, error) {
+	return c.ListAttachmentsQueryCtx(context.Background(), id, query)
+}
 
-	endpoint, err := c.Api.Get(endpoint, &tempFiles)
+func (c *Client) ListAttachmentsQueryCtx(ctx context.Context, id int64, query *invoiced.Query) (_ invoiced.Files, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "estimate.list_attachments")
+	defer func() { op.End(err) }()
 
+	endpoint, err := query.Endpoint("/estimates/" + strconv.FormatInt(id, 10) + "/attachments")
 	if err != nil {
 		return nil, err
 	}
 
-	files = append(files, tempFiles...)
+	return invoiced.CollectAll[invoiced.Files](ctx, c.Api, endpoint)
+}
+
+func (c *Client) DownloadPDF(id int64, w io.Writer) (*invoiced.Download, error) {
+	return c.DownloadPDFCtx(context.Background(), id, w)
+}
+
+func (c *Client) DownloadPDFCtx(ctx context.Context, id int64, w io.Writer) (_ *invoiced.Download, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "estimate.download_pdf")
+	defer func() { op.End(err) }()
 
-	if endpoint != "" {
-		goto NEXT
+	estimate := new(invoiced.Estimate)
+	if _, err = c.Api.GetCtx(ctx, c.resource().Endpoint(id), estimate); err != nil {
+		return nil, err
 	}
 
-	return files, nil
+	return c.Api.DownloadCtx(ctx, estimate.PdfUrl, w)
 }
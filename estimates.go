@@ -2,6 +2,7 @@ package invoiced
 
 import (
 	"encoding/json"
+	"time"
 )
 
 type EstimateRequest struct {
@@ -31,37 +32,37 @@ type EstimateRequest struct {
 }
 
 type Estimate struct {
-	Approved               string                 `json:"approved"`
-	Attachments            []int64                `json:"attachments"`
-	Closed                 bool                   `json:"closed"`
-	CreatedAt              int64                  `json:"created_at"`
-	Currency               string                 `json:"currency"`
-	Customer               int64                  `json:"customer"`
-	Date                   int64                  `json:"date"`
-	Deposit                float64                `json:"deposit"`
-	DepositPaid            bool                   `json:"deposit_paid"`
-	DisabledPaymentMethods []string               `json:"disabled_payment_methods"`
-	Discounts              []Discount             `json:"discounts"`
-	Draft                  bool                   `json:"draft"`
-	ExpirationDate         int64                  `json:"expiration_date"`
-	Id                     int64                  `json:"id"`
-	Invoice                int64                  `json:"invoice"`
-	Items                  []LineItem             `json:"items"`
-	Metadata               map[string]interface{} `json:"metadata"`
-	Name                   string                 `json:"name"`
-	Notes                  string                 `json:"notes"`
-	Number                 string                 `json:"number"`
-	Object                 string                 `json:"object"`
-	PaymentTerms           string                 `json:"payment_terms"`
-	PdfUrl                 string                 `json:"pdf_url"`
-	PurchaseOrder          string                 `json:"purchase_order"`
-	ShipTo                 string                 `json:"ship_to"`
-	Status                 string                 `json:"status"`
-	Subtotal               float64                `json:"subtotal"`
-	Taxes                  []Tax                  `json:"taxes"`
-	Total                  float64                `json:"total"`
-	UpdatedAt              int64                  `json:"updated_at"`
-	Url                    string                 `json:"url"`
+	Approved               string                      `json:"approved"`
+	Attachments            []int64                     `json:"attachments"`
+	Closed                 bool                        `json:"closed"`
+	CreatedAt              int64                       `json:"created_at"`
+	Currency               string                      `json:"currency"`
+	Customer               Expandable[Customer, int64] `json:"customer"`
+	Date                   int64                       `json:"date"`
+	Deposit                float64                     `json:"deposit"`
+	DepositPaid            bool                        `json:"deposit_paid"`
+	DisabledPaymentMethods []string                    `json:"disabled_payment_methods"`
+	Discounts              []Discount                  `json:"discounts"`
+	Draft                  bool                        `json:"draft"`
+	ExpirationDate         int64                       `json:"expiration_date"`
+	Id                     int64                       `json:"id"`
+	Invoice                Expandable[Invoice, int64]  `json:"invoice"`
+	Items                  []LineItem                  `json:"items"`
+	Metadata               map[string]interface{}      `json:"metadata"`
+	Name                   string                      `json:"name"`
+	Notes                  string                      `json:"notes"`
+	Number                 string                      `json:"number"`
+	Object                 string                      `json:"object"`
+	PaymentTerms           PaymentTerms                `json:"payment_terms"`
+	PdfUrl                 string                      `json:"pdf_url"`
+	PurchaseOrder          string                      `json:"purchase_order"`
+	ShipTo                 string                      `json:"ship_to"`
+	Status                 EstimateStatus              `json:"status"`
+	Subtotal               float64                     `json:"subtotal"`
+	Taxes                  []Tax                       `json:"taxes"`
+	Total                  float64                     `json:"total"`
+	UpdatedAt              int64                       `json:"updated_at"`
+	Url                    string                      `json:"url"`
 }
 
 type Estimates []*Estimate
@@ -71,3 +72,27 @@ func (i *Estimate) String() string {
 
 	return string(b)
 }
+
+func (i *Estimate) TotalMoney() (Money, error) {
+	return CheckedMoneyFromFloat(i.Total, i.Currency)
+}
+
+func (i *Estimate) SubtotalMoney() (Money, error) {
+	return CheckedMoneyFromFloat(i.Subtotal, i.Currency)
+}
+
+func (i *Estimate) CreatedAtTime() time.Time {
+	return Time(i.CreatedAt)
+}
+
+func (i *Estimate) DateTime() time.Time {
+	return Time(i.Date)
+}
+
+func (i *Estimate) ExpirationDateTime() time.Time {
+	return Time(i.ExpirationDate)
+}
+
+func (i *Estimate) UpdatedAtTime() time.Time {
+	return Time(i.UpdatedAt)
+}
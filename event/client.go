@@ -1,6 +1,7 @@
 package event
 
 import (
+	"context"
 	"github.com/Invoiced/invoiced-go/v2"
 	"strconv"
 )
@@ -9,7 +10,20 @@ type Client struct {
 	*invoiced.Api
 }
 
+func (c *Client) resource() invoiced.Resource[invoiced.Event, struct{}, int64] {
+	return invoiced.NewResource[invoiced.Event, struct{}, int64](c.Api, "event", "/events")
+}
+
+// ListAllByDatesAndUser is a shorthand for ListAllQuery with
+// Query.StartDate, Query.EndDate, Query.Filter("user_id", user) and
+// Query.Set("related_to", objectType+","+id).
 func (c *Client) ListAllByDatesAndUser(filter *invoiced.Filter, sort *invoiced.Sort, startDate int64, endDate int64, user string, objectType string, objectID int64) (invoiced.Events, error) {
+	return c.ListAllByDatesAndUserCtx(context.Background(), filter, sort, startDate, endDate, user, objectType, objectID)
+}
+
+func (c *Client) ListAllByDatesAndUserCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort, startDate int64, endDate int64, user string, objectType string, objectID int64) (_ invoiced.Events, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "event.list_all_by_dates_and_user")
+	defer func() { op.End(err) }()
 
 	if len(user) > 0 {
 		if filter == nil {
@@ -31,100 +45,105 @@ func (c *Client) ListAllByDatesAndUser(filter *invoiced.Filter, sort *invoiced.S
 		endpoint = invoiced.AddQueryParameter(endpoint, "related_to", relatesTo)
 	}
 
-	events := make(invoiced.Events, 0)
-
-NEXT:
-	tmpEvents := make(invoiced.Events, 0)
-
-	endpoint, err := c.Api.Get(endpoint, &tmpEvents)
-
-	if err != nil {
-		return nil, err
-	}
-
-	events = append(events, tmpEvents...)
-
-	if endpoint != "" {
-		goto NEXT
-	}
-
-	return events, nil
+	return invoiced.CollectAll[invoiced.Events](ctx, c.Api, endpoint)
 }
 
+// ListAllByDatesAndEventType is a shorthand for ListAllQuery with
+// Query.StartDate, Query.EndDate and Query.Set("type", objectType).
 func (c *Client) ListAllByDatesAndEventType(filter *invoiced.Filter, sort *invoiced.Sort, startDate int64, endDate int64, objectType string) (invoiced.Events, error) {
+	return c.ListAllByDatesAndEventTypeCtx(context.Background(), filter, sort, startDate, endDate, objectType)
+}
+
+func (c *Client) ListAllByDatesAndEventTypeCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort, startDate int64, endDate int64, objectType string) (_ invoiced.Events, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "event.list_all_by_dates_and_event_type")
+	defer func() { op.End(err) }()
 
 	endpoint := invoiced.AddFilterAndSort("/events", filter, sort)
 	endpoint = invoiced.AddQueryParameter(endpoint, "start_date", strconv.FormatInt(startDate, 10))
 	endpoint = invoiced.AddQueryParameter(endpoint, "end_date", strconv.FormatInt(endDate, 10))
 	endpoint = invoiced.AddQueryParameter(endpoint, "type", objectType)
 
-	events := make(invoiced.Events, 0)
-
-NEXT:
-	tmpEvents := make(invoiced.Events, 0)
-
-	endpoint, err := c.Api.Get(endpoint, &tmpEvents)
-
-	if err != nil {
-		return nil, err
-	}
-
-	events = append(events, tmpEvents...)
-
-	if endpoint != "" {
-		goto NEXT
-	}
-
-	return events, nil
+	return invoiced.CollectAll[invoiced.Events](ctx, c.Api, endpoint)
 }
 
 func (c *Client) ListAll(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Events, error) {
-	endpoint := "/events"
-	endpoint = invoiced.AddFilterAndSort(endpoint, filter, sort)
+	return c.ListAllCtx(context.Background(), filter, sort)
+}
 
-	events := make(invoiced.Events, 0)
+func (c *Client) ListAllCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Events, error) {
+	return c.resource().ListAllCtx(ctx, filter, sort)
+}
 
-NEXT:
-	tmpEvents := make(invoiced.Events, 0)
+func (c *Client) Iterate(filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.Event] {
+	return c.IterateCtx(context.Background(), filter, sort)
+}
 
-	endpoint, err := c.Api.Get(endpoint, &tmpEvents)
+func (c *Client) IterateCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.Event] {
+	return c.resource().IterateCtx(ctx, filter, sort)
+}
 
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) List(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Events, string, error) {
+	return c.ListCtx(context.Background(), filter, sort)
+}
 
-	events = append(events, tmpEvents...)
+func (c *Client) ListCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Events, string, error) {
+	return c.resource().ListCtx(ctx, filter, sort)
+}
 
-	if endpoint != "" {
-		goto NEXT
-	}
+func (c *Client) ListQuery(query *invoiced.Query) (invoiced.Events, string, error) {
+	return c.ListQueryCtx(context.Background(), query)
+}
 
-	return events, nil
+func (c *Client) ListQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.Events, string, error) {
+	return c.resource().ListQueryCtx(ctx, query)
 }
 
-func (c *Client) List(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Events, string, error) {
-	endpoint := "/events"
-	endpoint = invoiced.AddFilterAndSort(endpoint, filter, sort)
+func (c *Client) ListAllQuery(query *invoiced.Query) (invoiced.Events, error) {
+	return c.ListAllQueryCtx(context.Background(), query)
+}
 
-	events := make(invoiced.Events, 0)
+func (c *Client) ListAllQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.Events, error) {
+	return c.resource().ListAllQueryCtx(ctx, query)
+}
 
-	nextEndpoint, err := c.Api.Get(endpoint, &events)
+func (c *Client) IterateQuery(query *invoiced.Query) *invoiced.Iter[*invoiced.Event] {
+	return c.IterateQueryCtx(context.Background(), query)
+}
 
-	if err != nil {
-		return nil, "", err
-	}
+func (c *Client) IterateQueryCtx(ctx context.Context, query *invoiced.Query) *invoiced.Iter[*invoiced.Event] {
+	return c.resource().IterateQueryCtx(ctx, query)
+}
 
-	return events, nextEndpoint, nil
+func (c *Client) Count() (int64, error) {
+	return c.CountCtx(context.Background())
+}
+
+func (c *Client) CountCtx(ctx context.Context) (int64, error) {
+	return c.resource().CountCtx(ctx)
 }
 
 func (c *Client) Retrieve(id int64) (*invoiced.Event, error) {
+	return c.RetrieveCtx(context.Background(), id)
+}
+
+func (c *Client) RetrieveCtx(ctx context.Context, id int64) (_ *invoiced.Event, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "event.retrieve")
+	defer func() { op.End(err) }()
+
 	resp := new(invoiced.Event)
-	_, err := c.Api.Get("/events/"+strconv.FormatInt(id, 10)+"?include=user", resp)
+	_, err = c.Api.GetCtx(ctx, "/events/"+strconv.FormatInt(id, 10)+"?include=user", resp)
 	return resp, err
 }
 
 func (c *Client) RetrieveWithUser(id int64) (*invoiced.Event, error) {
+	return c.RetrieveWithUserCtx(context.Background(), id)
+}
+
+func (c *Client) RetrieveWithUserCtx(ctx context.Context, id int64) (_ *invoiced.Event, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "event.retrieve_with_user")
+	defer func() { op.End(err) }()
+
 	resp := new(invoiced.Event)
-	_, err := c.Api.Get("/events/"+strconv.FormatInt(id, 10)+"?include=user", resp)
+	_, err = c.Api.GetCtx(ctx, "/events/"+strconv.FormatInt(id, 10)+"?include=user", resp)
 	return resp, err
 }
@@ -4,15 +4,21 @@ import (
 	"encoding/json"
 	"errors"
 	"strings"
+	"time"
 )
 
 type Event struct {
-	Id        int64           `json:"id"`
-	Object    string          `json:"object"`
-	Type      string          `json:"type"`
-	Timestamp int64           `json:"timestamp"`
-	Data      json.RawMessage `json:"data"`
-	User      *User           `json:"user"`
+	Id        int64                   `json:"id"`
+	Object    string                  `json:"object"`
+	Type      EventType               `json:"type"`
+	Timestamp int64                   `json:"timestamp"`
+	Data      json.RawMessage         `json:"data"`
+	User      Expandable[User, int64] `json:"user"`
+}
+
+// Time returns when the event happened.
+func (e *Event) Time() time.Time {
+	return Time(e.Timestamp)
 }
 
 type EventObject struct {
@@ -0,0 +1,113 @@
+package invoiced
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Expandable is a reference to another object, which the API returns as the
+// id of the object unless the reference is expanded, in which case it
+// returns the whole object:
+//
+//	invoices, err := client.Invoice.ListAllQuery(invoiced.NewQuery().Expand("customer"))
+//	name := invoices[0].Customer.Object.Name
+//
+// Id is always set when the reference is, while Object is only set when the
+// reference was expanded. A reference marshals back to what it was
+// unmarshalled from, and the zero Expandable to null.
+type Expandable[T any, I ID] struct {
+	Id     I
+	Object *T
+}
+
+// Ref returns a reference to the object with the given id.
+func Ref[T any, I ID](id I) Expandable[T, I] {
+	return Expandable[T, I]{Id: id}
+}
+
+// Expanded returns a reference holding the given object, which has the
+// given id.
+func Expanded[T any, I ID](id I, object *T) Expandable[T, I] {
+	return Expandable[T, I]{Id: id, Object: object}
+}
+
+// IsSet reports whether the reference points to an object.
+func (e Expandable[T, I]) IsSet() bool {
+	var zero I
+
+	return e.Id != zero || e.Object != nil
+}
+
+// IsExpanded reports whether the referenced object is held by the reference.
+func (e Expandable[T, I]) IsExpanded() bool {
+	return e.Object != nil
+}
+
+// Resolve returns the referenced object, retrieving it with retrieve when
+// the reference was not expanded, e.g.
+//
+//	customer, err := invoice.Customer.Resolve(ctx, client.Customer.RetrieveCtx)
+//
+// The retrieved object is kept, so that it is only retrieved once. Resolve
+// returns nil when the reference is not set.
+func (e *Expandable[T, I]) Resolve(ctx context.Context, retrieve func(context.Context, I) (*T, error)) (*T, error) {
+	if e.Object != nil || !e.IsSet() {
+		return e.Object, nil
+	}
+
+	object, err := retrieve(ctx, e.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	e.Object = object
+
+	return object, nil
+}
+
+func (e Expandable[T, I]) MarshalJSON() ([]byte, error) {
+	if e.Object != nil {
+		return json.Marshal(e.Object)
+	}
+
+	if !e.IsSet() {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(e.Id)
+}
+
+func (e *Expandable[T, I]) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	*e = Expandable[T, I]{}
+
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		return nil
+	case len(data) > 0 && data[0] == '{':
+		ref := struct {
+			Id I `json:"id"`
+		}{}
+
+		if err := json.Unmarshal(data, &ref); err != nil {
+			return fmt.Errorf("invalid id of expanded object: %w", err)
+		}
+
+		object := new(T)
+		if err := json.Unmarshal(data, object); err != nil {
+			return err
+		}
+
+		e.Id, e.Object = ref.Id, object
+
+		return nil
+	default:
+		if err := json.Unmarshal(data, &e.Id); err != nil {
+			return fmt.Errorf("invalid reference %s: %w", data, err)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,138 @@
+package invoiced
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Invoiced/invoiced-go/v2/invdmockserver"
+)
+
+func TestExpandableUnmarshal(t *testing.T) {
+	var subscription Subscription
+
+	err := json.Unmarshal([]byte(`{"id":1,"customer":{"id":15444,"name":"Acme"},"plan":"starter"}`), &subscription)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if subscription.Customer.Id != 15444 || !subscription.Customer.IsExpanded() || subscription.Customer.Object.Name != "Acme" {
+		t.Fatal("Unexpected customer", subscription.Customer)
+	}
+
+	if subscription.Plan.Id != "starter" || subscription.Plan.IsExpanded() {
+		t.Fatal("Unexpected plan", subscription.Plan)
+	}
+
+	var invoice Invoice
+	if err := json.Unmarshal([]byte(`{"id":2,"customer":null}`), &invoice); err != nil {
+		t.Fatal(err)
+	}
+
+	if invoice.Customer.IsSet() || invoice.Subscription.IsSet() {
+		t.Fatal("Expected no customer or subscription")
+	}
+}
+
+func TestExpandableMemberUser(t *testing.T) {
+	var member Member
+	if err := json.Unmarshal([]byte(`{"id":1,"user":{"id":2,"email":"jane@example.com"}}`), &member); err != nil {
+		t.Fatal(err)
+	}
+
+	if member.User.Id != 2 || !member.User.IsExpanded() || member.User.Object.Email != "jane@example.com" {
+		t.Fatal("Unexpected user", member.User)
+	}
+}
+
+func TestExpandableUnmarshalError(t *testing.T) {
+	var invoice Invoice
+
+	if err := json.Unmarshal([]byte(`{"id":2,"customer":"acme"}`), &invoice); err == nil {
+		t.Fatal("Expected an invalid customer id to fail")
+	}
+
+	if err := json.Unmarshal([]byte(`{"id":2,"customer":{"id":"acme"}}`), &invoice); err == nil {
+		t.Fatal("Expected an invalid expanded customer id to fail")
+	}
+
+	if err := json.Unmarshal([]byte(`{"id":2,"customer":{"id":1,"name":5}}`), &invoice); err == nil {
+		t.Fatal("Expected an invalid expanded customer to fail")
+	}
+}
+
+func TestExpandableRoundTrip(t *testing.T) {
+	type subscription struct {
+		Customer Expandable[Customer, int64] `json:"customer"`
+		Plan     Expandable[Plan, string]    `json:"plan"`
+	}
+
+	var ref subscription
+	if err := json.Unmarshal([]byte(`{"customer":15444,"plan":null}`), &ref); err != nil {
+		t.Fatal(err)
+	}
+
+	if b, err := json.Marshal(ref); err != nil || string(b) != `{"customer":15444,"plan":null}` {
+		t.Fatal("Unexpected JSON", string(b), err)
+	}
+
+	var expanded subscription
+	if err := json.Unmarshal([]byte(`{"customer":15444,"plan":{"id":"starter","name":"Starter"}}`), &expanded); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := json.Marshal(expanded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var again subscription
+	if err := json.Unmarshal(b, &again); err != nil {
+		t.Fatal(err)
+	}
+
+	if again.Plan.Id != "starter" || !again.Plan.IsExpanded() || again.Plan.Object.Name != "Starter" {
+		t.Fatal("Unexpected plan", string(b))
+	}
+}
+
+func TestExpandableResolve(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(true, invdmockserver.ScriptedResponse{Status: 200, Body: Customer{Id: 15444, Name: "Acme"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	customers := NewResource[Customer, CustomerRequest, int64](NewMockApi("whatever", server.Server), "customer", "/customers")
+	ref := Ref[Customer, int64](15444)
+
+	for i := 0; i < 2; i++ {
+		customer, err := ref.Resolve(context.Background(), customers.RetrieveCtx)
+		if err != nil || customer.Name != "Acme" {
+			t.Fatal("Unexpected customer", customer, err)
+		}
+	}
+
+	if len(server.Requests()) != 1 || server.Requests()[0].Url != "/customers/15444" {
+		t.Fatal("Expected the customer to be retrieved once", server.Requests())
+	}
+
+	var unset Expandable[Customer, int64]
+	if customer, err := unset.Resolve(context.Background(), customers.RetrieveCtx); customer != nil || err != nil {
+		t.Fatal("Expected nothing to resolve", customer, err)
+	}
+
+	failing := Ref[Customer, int64](1)
+	_, err = failing.Resolve(context.Background(), func(context.Context, int64) (*Customer, error) {
+		return nil, errors.New("not found")
+	})
+	if err == nil || failing.IsExpanded() {
+		t.Fatal("Expected the retrieval to fail")
+	}
+
+	expanded := Expanded(int64(2), &Customer{Id: 2})
+	if customer, _ := expanded.Resolve(context.Background(), nil); customer.Id != 2 {
+		t.Fatal("Expected the expanded customer")
+	}
+}
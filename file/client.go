@@ -1,32 +1,81 @@
 package file
 
 import (
+	"context"
 	"github.com/Invoiced/invoiced-go/v2"
-	"strconv"
+	"io"
 )
 
 type Client struct {
 	*invoiced.Api
 }
 
+func (c *Client) resource() invoiced.Resource[invoiced.File, invoiced.FileRequest, int64] {
+	return invoiced.NewResource[invoiced.File, invoiced.FileRequest, int64](c.Api, "file", "/files")
+}
+
 func (c *Client) Create(request *invoiced.FileRequest) (*invoiced.File, error) {
-	resp := new(invoiced.File)
-	err := c.Api.Create("/files", request, resp)
-	return resp, err
+	return c.CreateCtx(context.Background(), request)
+}
+
+func (c *Client) CreateCtx(ctx context.Context, request *invoiced.FileRequest) (*invoiced.File, error) {
+	return c.resource().CreateCtx(ctx, request)
 }
 
 func (c *Client) CreateAndUploadFile(filePath, fileType string) (*invoiced.File, error) {
+	return c.CreateAndUploadFileCtx(context.Background(), filePath, fileType)
+}
+
+func (c *Client) CreateAndUploadFileCtx(ctx context.Context, filePath, fileType string) (_ *invoiced.File, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "file.create_and_upload_file")
+	defer func() { op.End(err) }()
+
 	resp := new(invoiced.File)
-	err := c.Api.Upload("/files", filePath, "file", nil, fileType, resp)
+	err = c.Api.UploadCtx(ctx, "/files", filePath, "file", nil, fileType, resp)
 	return resp, err
 }
 
 func (c *Client) Retrieve(id int64) (*invoiced.File, error) {
+	return c.RetrieveCtx(context.Background(), id)
+}
+
+func (c *Client) RetrieveCtx(ctx context.Context, id int64) (*invoiced.File, error) {
+	return c.resource().RetrieveCtx(ctx, id)
+}
+
+func (c *Client) Delete(id int64) error {
+	return c.DeleteCtx(context.Background(), id)
+}
+
+func (c *Client) DeleteCtx(ctx context.Context, id int64) error {
+	return c.resource().DeleteCtx(ctx, id)
+}
+
+func (c *Client) CreateAndUploadReader(file *invoiced.UploadFile) (*invoiced.File, error) {
+	return c.CreateAndUploadReaderCtx(context.Background(), file)
+}
+
+func (c *Client) CreateAndUploadReaderCtx(ctx context.Context, file *invoiced.UploadFile) (_ *invoiced.File, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "file.create_and_upload_reader")
+	defer func() { op.End(err) }()
+
 	resp := new(invoiced.File)
-	_, err := c.Api.Get("/files/"+strconv.FormatInt(id, 10), resp)
+	err = c.Api.UploadReaderCtx(ctx, "/files", "file", file, nil, resp)
 	return resp, err
 }
 
-func (c *Client) Delete(id int64) error {
-	return c.Api.Delete("/files/" + strconv.FormatInt(id, 10))
+func (c *Client) Download(id int64, w io.Writer) (*invoiced.Download, error) {
+	return c.DownloadCtx(context.Background(), id, w)
+}
+
+func (c *Client) DownloadCtx(ctx context.Context, id int64, w io.Writer) (_ *invoiced.Download, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "file.download")
+	defer func() { op.End(err) }()
+
+	file := new(invoiced.File)
+	if _, err = c.Api.GetCtx(ctx, c.resource().Endpoint(id), file); err != nil {
+		return nil, err
+	}
+
+	return c.Api.DownloadCtx(ctx, file.Url, w)
 }
@@ -3,6 +3,7 @@ package file
 import (
 	"github.com/Invoiced/invoiced-go/v2"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 	"github.com/Invoiced/invoiced-go/v2/invdmockserver"
@@ -81,3 +82,34 @@ func TestFile_Retrieve(t *testing.T) {
 		t.Fatal("Error messages do not match up")
 	}
 }
+
+func TestFile_CreateAndUploadReader(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(true, invdmockserver.ScriptedResponse{
+		Status: 200,
+		Body:   &invoiced.File{Id: 1234, Name: "statement.pdf"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer server.Close()
+
+	client := Client{invoiced.NewMockApi("test api key", server.Server)}
+
+	file, err := client.CreateAndUploadReader(&invoiced.UploadFile{
+		Reader: strings.NewReader("%PDF-1.4 statement"),
+		Name:   "statement.pdf",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if file.Id != 1234 {
+		t.Fatal("Unexpected file", file)
+	}
+
+	body := server.Requests()[0].Body
+	if !strings.Contains(body, `filename="statement.pdf"`) || !strings.Contains(body, "Content-Type: application/pdf") || !strings.Contains(body, "%PDF-1.4 statement") {
+		t.Fatal("Unexpected upload", body)
+	}
+}
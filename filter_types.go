@@ -0,0 +1,167 @@
+package invoiced
+
+import "reflect"
+
+// The typed filters list the fields each resource can be filtered by, so
+// that a misspelled field does not compile, where Filter.Set would silently
+// send a filter the API ignores. Fields left at their zero value are not
+// filtered on; booleans are pointers so that false can be filtered on:
+//
+//	filter := invoiced.InvoiceFilter{
+//		Customer: 1234,
+//		Status:   invoiced.InvoiceStatusPastDue,
+//		AutoPay:  invoiced.Bool(false),
+//	}
+//	invoices, err := client.Invoice.ListAll(filter.Filter(), nil)
+//
+// Filter converts them to a Filter, which can also be added to a Query with
+// Query.WithFilter. Each field names its API field with a filter tag.
+
+type InvoiceFilter struct {
+	Customer     int64         `filter:"customer"`
+	Number       string        `filter:"number"`
+	Status       InvoiceStatus `filter:"status"`
+	Currency     string        `filter:"currency"`
+	PaymentTerms PaymentTerms  `filter:"payment_terms"`
+	Paid         *bool         `filter:"paid"`
+	Closed       *bool         `filter:"closed"`
+	Draft        *bool         `filter:"draft"`
+	Sent         *bool         `filter:"sent"`
+	AutoPay      *bool         `filter:"autopay"`
+}
+
+func (f *InvoiceFilter) Filter() *Filter {
+	return structFilter(f)
+}
+
+type CreditNoteFilter struct {
+	Customer int64            `filter:"customer"`
+	Invoice  int64            `filter:"invoice"`
+	Number   string           `filter:"number"`
+	Status   CreditNoteStatus `filter:"status"`
+	Currency string           `filter:"currency"`
+	Paid     *bool            `filter:"paid"`
+	Closed   *bool            `filter:"closed"`
+	Draft    *bool            `filter:"draft"`
+	Sent     *bool            `filter:"sent"`
+}
+
+func (f *CreditNoteFilter) Filter() *Filter {
+	return structFilter(f)
+}
+
+type EstimateFilter struct {
+	Customer int64          `filter:"customer"`
+	Invoice  int64          `filter:"invoice"`
+	Number   string         `filter:"number"`
+	Status   EstimateStatus `filter:"status"`
+	Currency string         `filter:"currency"`
+	Approved *bool          `filter:"approved"`
+	Closed   *bool          `filter:"closed"`
+	Draft    *bool          `filter:"draft"`
+	Sent     *bool          `filter:"sent"`
+}
+
+func (f *EstimateFilter) Filter() *Filter {
+	return structFilter(f)
+}
+
+type CustomerFilter struct {
+	Name           string       `filter:"name"`
+	Number         string       `filter:"number"`
+	Email          string       `filter:"email"`
+	Type           CustomerType `filter:"type"`
+	Currency       string       `filter:"currency"`
+	Country        string       `filter:"country"`
+	PaymentTerms   PaymentTerms `filter:"payment_terms"`
+	ChasingCadence int64        `filter:"chasing_cadence"`
+	Owner          int64        `filter:"owner"`
+	ParentCustomer int64        `filter:"parent_customer"`
+	AutoPay        *bool        `filter:"autopay"`
+	Chase          *bool        `filter:"chase"`
+	CreditHold     *bool        `filter:"credit_hold"`
+	Taxable        *bool        `filter:"taxable"`
+}
+
+func (f *CustomerFilter) Filter() *Filter {
+	return structFilter(f)
+}
+
+type PaymentFilter struct {
+	Customer  int64         `filter:"customer"`
+	Currency  string        `filter:"currency"`
+	Method    PaymentMethod `filter:"method"`
+	Reference string        `filter:"reference"`
+	Source    string        `filter:"source"`
+	Voided    *bool         `filter:"voided"`
+}
+
+func (f *PaymentFilter) Filter() *Filter {
+	return structFilter(f)
+}
+
+type SubscriptionFilter struct {
+	Customer          int64              `filter:"customer"`
+	Plan              string             `filter:"plan"`
+	Status            SubscriptionStatus `filter:"status"`
+	CancelAtPeriodEnd *bool              `filter:"cancel_at_period_end"`
+	Paused            *bool              `filter:"paused"`
+}
+
+func (f *SubscriptionFilter) Filter() *Filter {
+	return structFilter(f)
+}
+
+type PlanFilter struct {
+	Item        string      `filter:"catalog_item"`
+	Currency    string      `filter:"currency"`
+	Interval    Interval    `filter:"interval"`
+	PricingMode PricingMode `filter:"pricing_mode"`
+}
+
+func (f *PlanFilter) Filter() *Filter {
+	return structFilter(f)
+}
+
+type TaskFilter struct {
+	Customer int64         `filter:"customer_id"`
+	Action   ChasingAction `filter:"action"`
+	User     int64         `filter:"user_id"`
+	Complete *bool         `filter:"complete"`
+}
+
+func (f *TaskFilter) Filter() *Filter {
+	return structFilter(f)
+}
+
+// structFilter converts the fields of a typed filter that are not zero. The
+// typed filters only have strings, integers and pointers to booleans, which
+// formatQueryValue always accepts.
+func structFilter(v interface{}) *Filter {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return nil
+	}
+
+	rv = rv.Elem()
+	filter := NewFilter()
+
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Field(i)
+
+		if field.Kind() == reflect.Pointer {
+			if field.IsNil() {
+				continue
+			}
+
+			field = field.Elem()
+		} else if field.IsZero() {
+			continue
+		}
+
+		value, _ := formatQueryValue(field.Interface())
+		filter.params[rv.Type().Field(i).Tag.Get("filter")] = value
+	}
+
+	return filter
+}
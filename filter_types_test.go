@@ -0,0 +1,80 @@
+package invoiced
+
+import (
+	"testing"
+
+	"github.com/Invoiced/invoiced-go/v2/invdmockserver"
+)
+
+func TestInvoiceFilter(t *testing.T) {
+	filter := InvoiceFilter{
+		Customer: 1234,
+		Status:   InvoiceStatusPastDue,
+		Paid:     Bool(false),
+		AutoPay:  Bool(true),
+	}
+
+	expected := "filter%5Bautopay%5D=1&filter%5Bcustomer%5D=1234&filter%5Bpaid%5D=0&filter%5Bstatus%5D=past_due"
+	if s := filter.Filter().String(); s != expected {
+		t.Fatal("Unexpected filter", s)
+	}
+
+	if s := (&InvoiceFilter{}).Filter().String(); s != "" {
+		t.Fatal("Expected an empty filter", s)
+	}
+
+	var nilFilter *InvoiceFilter
+	if nilFilter.Filter() != nil {
+		t.Fatal("Expected a nil filter")
+	}
+}
+
+func TestTypedFilters(t *testing.T) {
+	tests := []struct {
+		filter   *Filter
+		expected string
+	}{
+		{(&CreditNoteFilter{Invoice: 5, Draft: Bool(false)}).Filter(), "filter%5Bdraft%5D=0&filter%5Binvoice%5D=5"},
+		{(&EstimateFilter{Status: EstimateStatusApproved}).Filter(), "filter%5Bstatus%5D=approved"},
+		{(&CustomerFilter{Type: CustomerTypeCompany, PaymentTerms: NetTerms(30)}).Filter(), "filter%5Bpayment_terms%5D=NET+30&filter%5Btype%5D=company"},
+		{(&PaymentFilter{Method: PaymentMethodAch, Voided: Bool(false)}).Filter(), "filter%5Bmethod%5D=ach&filter%5Bvoided%5D=0"},
+		{(&SubscriptionFilter{Plan: "pro", Status: SubscriptionStatusActive}).Filter(), "filter%5Bplan%5D=pro&filter%5Bstatus%5D=active"},
+		{(&PlanFilter{Interval: IntervalMonth}).Filter(), "filter%5Binterval%5D=month"},
+		{(&TaskFilter{User: 7, Complete: Bool(true)}).Filter(), "filter%5Bcomplete%5D=1&filter%5Buser_id%5D=7"},
+	}
+
+	for _, test := range tests {
+		if s := test.filter.String(); s != test.expected {
+			t.Fatal("Unexpected filter", s, "expected", test.expected)
+		}
+	}
+}
+
+func TestTypedFilterList(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(true,
+		invdmockserver.ScriptedResponse{Status: 200, Body: Coupons{}},
+		invdmockserver.ScriptedResponse{Status: 200, Body: Coupons{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	coupons := NewResource[Coupon, CouponRequest, string](NewMockApi("whatever", server.Server), "coupon", "/coupons")
+	filter := PlanFilter{Currency: "usd"}
+
+	if _, err := coupons.ListAll(filter.Filter(), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := coupons.ListAllQuery(NewQuery().WithFilter(filter.Filter()).PerPage(10)); err != nil {
+		t.Fatal(err)
+	}
+
+	if url := server.Requests()[0].Url; url != "/coupons?filter%5Bcurrency%5D=usd" {
+		t.Fatal("Unexpected request", url)
+	}
+
+	if url := server.Requests()[1].Url; url != "/coupons?filter%5Bcurrency%5D=usd&per_page=10" {
+		t.Fatal("Unexpected request", url)
+	}
+}
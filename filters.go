@@ -3,8 +3,10 @@ package invoiced
 import (
 	"errors"
 	"net/url"
+	"reflect"
 	"sort"
 	"strconv"
+	"time"
 )
 
 type Filter struct {
@@ -26,7 +28,8 @@ func NewMetadataFilter() *Filter {
 	return f
 }
 
-// Can only set Numeric Types and Strings
+// Can only set Numeric Types, Strings and Times. Strings include the named
+// string types such as InvoiceStatus. Times are sent as Unix timestamps.
 func (f *Filter) Set(key string, value interface{}) error {
 	switch v := value.(type) {
 	case string:
@@ -41,8 +44,15 @@ func (f *Filter) Set(key string, value interface{}) error {
 		f.params[key] = strconv.FormatFloat(float64(v), 'f', 2, 64)
 	case float64:
 		f.params[key] = strconv.FormatFloat(float64(v), 'f', 2, 64)
+	case time.Time:
+		f.params[key] = strconv.FormatInt(v.Unix(), 10)
 	default:
-		return errors.New("Filter can only accept numeric (int32,int64,float32,float64) or string values")
+		if rv := reflect.ValueOf(value); rv.Kind() == reflect.String {
+			f.params[key] = rv.String()
+			break
+		}
+
+		return errors.New("Filter can only accept numeric (int32,int64,float32,float64), string or time.Time values")
 	}
 
 	return nil
@@ -29,6 +29,21 @@ func TestFilter(t *testing.T) {
 	}
 }
 
+func TestFilterEnum(t *testing.T) {
+	f := NewFilter()
+	if err := f.Set("status", InvoiceStatusPastDue); err != nil {
+		t.Fatal(err)
+	}
+
+	if f.Get("status") != "past_due" {
+		t.Fatal("Unexpected status", f.Get("status"))
+	}
+
+	if err := f.Set("status", []string{"paid"}); err == nil {
+		t.Fatal("Expected a slice to be rejected")
+	}
+}
+
 func TestMetadataFilter(t *testing.T) {
 	f := NewMetadataFilter()
 	err := f.Set("icp_number", 1)
@@ -1,3 +1,3 @@
 module github.com/Invoiced/invoiced-go/v2
 
-go 1.16
+go 1.23
@@ -0,0 +1,57 @@
+package invoiced
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey returns a context that makes POST requests issued with it
+// carry the given Idempotency-Key header. Pass it to any Ctx client method,
+// e.g. client.Invoice.PayCtx(invoiced.WithIdempotencyKey(ctx, key), id), so
+// that repeating the call with the same context after a timeout cannot charge
+// the customer twice. Every call made with the context sends the same key, so
+// derive a new context with its own key for each distinct operation.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// IdempotencyKeyFromContext returns the key set with WithIdempotencyKey.
+func IdempotencyKeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key, ok && key != ""
+}
+
+// SetAutoIdempotencyKeys makes every POST request that has no key of its own
+// carry a freshly generated Idempotency-Key. The key is kept for the retries
+// of that request, which lets the retry policy retry POSTs safely.
+func (c *Api) SetAutoIdempotencyKeys(enabled bool) {
+	c.autoIdempotencyKeys = enabled
+}
+
+// NewIdempotencyKey generates a random key suitable for WithIdempotencyKey.
+func NewIdempotencyKey() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	// format as a version 4 UUID
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	s := hex.EncodeToString(b)
+
+	return s[0:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+}
+
+func (c *Api) setIdempotencyKey(req *http.Request) {
+	if key, ok := IdempotencyKeyFromContext(req.Context()); ok {
+		req.Header.Set(idempotencyKeyHeader, key)
+	} else if c.autoIdempotencyKeys {
+		req.Header.Set(idempotencyKeyHeader, NewIdempotencyKey())
+	}
+}
@@ -0,0 +1,126 @@
+package invoiced
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/Invoiced/invoiced-go/v2/invdmockserver"
+)
+
+func TestNewIdempotencyKey(t *testing.T) {
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	key := NewIdempotencyKey()
+	if !uuid.MatchString(key) {
+		t.Fatal("Key is not a version 4 UUID", key)
+	}
+
+	if key == NewIdempotencyKey() {
+		t.Fatal("Keys should be unique")
+	}
+}
+
+func TestIdempotencyKeyFromContext(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(true,
+		invdmockserver.ScriptedResponse{Status: 200, Body: &Customer{Id: 1234}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	client := NewMockApi("whatever", server.Server)
+
+	ctx := WithIdempotencyKey(context.Background(), "charge-1234")
+
+	err = client.CreateCtx(ctx, "/charges", &CustomerRequest{Name: String("Jane")}, new(Customer))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.GetCtx(ctx, "/customers/1234", new(Customer))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.Create("/charges", &CustomerRequest{Name: String("Jane")}, new(Customer))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requests := server.Requests()
+
+	if key := requests[0].Header.Get(idempotencyKeyHeader); key != "charge-1234" {
+		t.Fatal("POST should carry the key from the context, got", key)
+	}
+
+	if key := requests[1].Header.Get(idempotencyKeyHeader); key != "" {
+		t.Fatal("GET should not carry an idempotency key, got", key)
+	}
+
+	if key := requests[2].Header.Get(idempotencyKeyHeader); key != "" {
+		t.Fatal("Keys should not be generated unless enabled, got", key)
+	}
+}
+
+func TestIdempotencyKeyManualRetry(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(true,
+		invdmockserver.ScriptedResponse{Status: 200, Body: &Invoice{Id: 1234}},
+		invdmockserver.ScriptedResponse{Status: 200, Body: &Invoice{Id: 1234}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	client := NewMockApi("whatever", server.Server)
+
+	ctx := WithIdempotencyKey(context.Background(), "pay-1234")
+
+	for i := 0; i < 2; i++ {
+		if err := client.PostWithoutDataCtx(ctx, "/invoices/1234/pay", new(Invoice)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i, request := range server.Requests() {
+		if key := request.Header.Get(idempotencyKeyHeader); key != "pay-1234" {
+			t.Fatal("POST", i, "should carry the key from the context, got", key)
+		}
+	}
+
+	if key, ok := IdempotencyKeyFromContext(ctx); !ok || key != "pay-1234" {
+		t.Fatal("Expected the key to stay in the context", key)
+	}
+}
+
+func TestAutoIdempotencyKeysAreStableAcrossRetries(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(true,
+		invdmockserver.ScriptedResponse{Status: 503, Body: NewAPIError("api_error", "try again", "")},
+		invdmockserver.ScriptedResponse{Status: 200, Body: &Customer{Id: 1234}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	client := NewMockApi("whatever", server.Server)
+	client.SetRetryPolicy(fastRetryPolicy())
+	client.SetAutoIdempotencyKeys(true)
+
+	err = client.PostWithoutData("/invoices/1234/pay", new(Invoice))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 2 {
+		t.Fatal("Expected the POST to be retried, got attempts:", len(requests))
+	}
+
+	key := requests[0].Header.Get(idempotencyKeyHeader)
+	if key == "" || key != requests[1].Header.Get(idempotencyKeyHeader) {
+		t.Fatal("Retries should reuse the generated key")
+	}
+}
@@ -0,0 +1,181 @@
+package invoiced
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Names of the metrics reported to an Instrumentation. Operation metrics
+// carry the "resource" and "operation" attributes, e.g. "invoice" and
+// "list_all", and an "outcome" of "ok" or "error". Request metrics carry the
+// HTTP "method" and "status".
+const (
+	MetricOperations        = "invoiced.operations"
+	MetricOperationDuration = "invoiced.operation.duration"
+	MetricRequests          = "invoiced.requests"
+	MetricRequestDuration   = "invoiced.request.duration"
+)
+
+// Attribute is a key/value pair attached to spans and metrics.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+func Attr(key string, value interface{}) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Span is a unit of traced work started by Instrumentation.StartSpan.
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	// End finishes the span; err is the outcome of the work, or nil.
+	End(err error)
+}
+
+// Instrumentation receives traces and metrics from the client. Every client
+// operation, such as "invoice.list_all", gets a span with a child span for
+// each HTTP request it makes, and updates the counters and latency
+// histograms named by the Metric constants. Adapters to tracing and metrics
+// libraries implement this interface.
+type Instrumentation interface {
+	StartSpan(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+	AddCounter(ctx context.Context, name string, delta int64, attrs ...Attribute)
+	RecordLatency(ctx context.Context, name string, latency time.Duration, attrs ...Attribute)
+}
+
+// NoopInstrumentation discards everything. It is the default.
+type NoopInstrumentation struct{}
+
+func (NoopInstrumentation) StartSpan(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+func (NoopInstrumentation) AddCounter(ctx context.Context, name string, delta int64, attrs ...Attribute) {
+}
+
+func (NoopInstrumentation) RecordLatency(ctx context.Context, name string, latency time.Duration, attrs ...Attribute) {
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(attrs ...Attribute) {}
+
+func (noopSpan) End(err error) {}
+
+// WithInstrumentation is the option form of Api.SetInstrumentation.
+func WithInstrumentation(instrumentation Instrumentation) Option {
+	return func(o *options) {
+		o.instrumentation = instrumentation
+	}
+}
+
+// SetInstrumentation makes the Api report to the given Instrumentation. A nil
+// value restores the no-op default.
+func (c *Api) SetInstrumentation(instrumentation Instrumentation) {
+	c.instrumentation = instrumentation
+}
+
+func (c *Api) instrument() Instrumentation {
+	if c.instrumentation == nil {
+		return NoopInstrumentation{}
+	}
+
+	return c.instrumentation
+}
+
+// Operation tracks a single client call, e.g. one ListAll, across all the
+// HTTP requests it makes.
+type Operation struct {
+	instrumentation Instrumentation
+	ctx             context.Context
+	span            Span
+	attrs           []Attribute
+	start           time.Time
+}
+
+// StartOperation is called by the resource clients at the beginning of every
+// call with a name such as "invoice.list_all". The returned context must be
+// used for the requests made by the call, and End called once it is done.
+func (c *Api) StartOperation(ctx context.Context, name string) (context.Context, *Operation) {
+	resource, operation := name, name
+	if i := strings.Index(name, "."); i >= 0 {
+		resource, operation = name[:i], name[i+1:]
+	}
+
+	attrs := []Attribute{Attr("resource", resource), Attr("operation", operation)}
+
+	instrumentation := c.instrument()
+	ctx, span := instrumentation.StartSpan(ctx, name, attrs...)
+
+	return ctx, &Operation{
+		instrumentation: instrumentation,
+		ctx:             ctx,
+		span:            span,
+		attrs:           attrs,
+		start:           time.Now(),
+	}
+}
+
+// End records the outcome of the operation.
+func (o *Operation) End(err error) {
+	attrs := append(o.attrs, outcomeAttrs(err)...)
+
+	o.instrumentation.AddCounter(o.ctx, MetricOperations, 1, attrs...)
+	o.instrumentation.RecordLatency(o.ctx, MetricOperationDuration, time.Since(o.start), attrs...)
+	o.span.End(err)
+}
+
+func outcomeAttrs(err error) []Attribute {
+	if err == nil {
+		return []Attribute{Attr("outcome", "ok")}
+	}
+
+	attrs := []Attribute{Attr("outcome", "error")}
+
+	apiError := new(APIError)
+	if errors.As(err, &apiError) && apiError.Type != "" {
+		attrs = append(attrs, Attr("error_type", apiError.Type))
+	}
+
+	return attrs
+}
+
+func (c *Api) instrumentRequests(next Handler) Handler {
+	return func(req *Request) (*http.Response, error) {
+		instrumentation := c.instrument()
+
+		ctx, span := instrumentation.StartSpan(req.Context(), "invoiced.request",
+			Attr("method", req.Method),
+			Attr("endpoint", req.Endpoint))
+
+		req.Request = req.WithContext(ctx)
+
+		start := time.Now()
+		resp, err := next(req)
+		latency := time.Since(start)
+
+		status := "error"
+		if err == nil {
+			status = strconv.Itoa(resp.StatusCode)
+			span.SetAttributes(Attr("status", resp.StatusCode))
+			if resp.StatusCode >= 400 {
+				span.End(errors.New(resp.Status))
+			} else {
+				span.End(nil)
+			}
+		} else {
+			span.End(err)
+		}
+
+		attrs := []Attribute{Attr("method", req.Method), Attr("status", status)}
+		instrumentation.AddCounter(ctx, MetricRequests, 1, attrs...)
+		instrumentation.RecordLatency(ctx, MetricRequestDuration, latency, attrs...)
+
+		return resp, err
+	}
+}
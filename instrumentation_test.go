@@ -0,0 +1,93 @@
+package invoiced
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Invoiced/invoiced-go/v2/invdmockserver"
+)
+
+func TestInstrumentationRecordsOperationsAndRequests(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(true,
+		invdmockserver.ScriptedResponse{Status: 200, Body: &Customer{Id: 1234}},
+		invdmockserver.ScriptedResponse{Status: 404, Body: NewAPIError("invalid_request", "not found", "")},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	recorder := NewMemoryRecorder()
+	client := NewMockApi("whatever", server.Server, WithInstrumentation(recorder))
+
+	ctx, op := client.StartOperation(context.Background(), "customer.retrieve")
+	_, err = client.GetCtx(ctx, "/customers/1234", new(Customer))
+	op.End(err)
+
+	ctx, op = client.StartOperation(context.Background(), "customer.retrieve")
+	_, err = client.GetCtx(ctx, "/customers/5678", new(Customer))
+	op.End(err)
+
+	if err == nil {
+		t.Fatal("Expected the second call to fail")
+	}
+
+	spans := recorder.Spans()
+	if len(spans) != 4 {
+		t.Fatal("Expected 4 spans, got", len(spans))
+	}
+
+	if spans[0].Name != "customer.retrieve" || spans[0].Attributes["resource"] != "customer" || spans[0].Attributes["operation"] != "retrieve" {
+		t.Fatal("Unexpected operation span", spans[0])
+	}
+
+	if spans[1].Name != "invoiced.request" || spans[1].Parent != "customer.retrieve" || spans[1].Attributes["status"] != 200 {
+		t.Fatal("Unexpected request span", spans[1])
+	}
+
+	for _, span := range spans {
+		if !span.Ended {
+			t.Fatal("Span was not ended", span.Name)
+		}
+	}
+
+	if spans[0].Err != nil || spans[2].Err == nil || spans[3].Err == nil {
+		t.Fatal("Span errors were not recorded")
+	}
+
+	if n := recorder.Counter(MetricOperations, Attr("resource", "customer"), Attr("operation", "retrieve")); n != 2 {
+		t.Fatal("Expected 2 operations, got", n)
+	}
+
+	if n := recorder.Counter(MetricOperations, Attr("outcome", "error"), Attr("error_type", "invalid_request")); n != 1 {
+		t.Fatal("Expected 1 failed operation, got", n)
+	}
+
+	if n := recorder.Counter(MetricRequests, Attr("method", "GET"), Attr("status", "404")); n != 1 {
+		t.Fatal("Expected 1 request with status 404, got", n)
+	}
+
+	if n := len(recorder.Latencies(MetricOperationDuration, Attr("resource", "customer"))); n != 2 {
+		t.Fatal("Expected 2 operation latencies, got", n)
+	}
+
+	if n := len(recorder.Latencies(MetricRequestDuration)); n != 2 {
+		t.Fatal("Expected 2 request latencies, got", n)
+	}
+
+	recorder.Reset()
+	if len(recorder.Spans()) != 0 || recorder.Counter(MetricRequests) != 0 {
+		t.Fatal("Recorder was not reset")
+	}
+}
+
+func TestNoopInstrumentationIsDefault(t *testing.T) {
+	client := New("key", false)
+
+	ctx, op := client.StartOperation(context.Background(), "invoice.list_all")
+	if ctx == nil || op == nil {
+		t.Fatal("StartOperation should work without instrumentation")
+	}
+
+	op.End(nil)
+}
@@ -70,3 +70,30 @@ func TestJsonMockServer(t *testing.T) {
 		t.Fatal("Incorrect Response From JsonMockServer, actual response => ", string(b), " ,expected resonse => ", expectedResponse)
 	}
 }
+
+func TestScriptedServer(t *testing.T) {
+	server, err := NewScriptedServer(false,
+		ScriptedResponse{Status: 503, Headers: map[string]string{"Retry-After": "1"}},
+		ScriptedResponse{Status: 200, Body: &JsonTest{Msg: "Hello World"}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	for _, expectedStatus := range []int{503, 200, 200} {
+		resp, err := http.Get(server.URL + "/customers")
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != expectedStatus {
+			t.Fatal("Status code is incorrect ", resp.StatusCode)
+		}
+	}
+
+	if len(server.Requests()) != 3 || server.Requests()[0].Url != "/customers" {
+		t.Fatal("Requests were not recorded")
+	}
+}
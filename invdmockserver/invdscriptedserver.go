@@ -0,0 +1,101 @@
+package invdmockserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+)
+
+// ScriptedResponse is one canned reply served by a ScriptedServer.
+type ScriptedResponse struct {
+	Status  int
+	Headers map[string]string
+	Body    interface{}
+}
+
+// RecordedRequest is a request received by a ScriptedServer.
+type RecordedRequest struct {
+	Method string
+	Url    string
+	Header http.Header
+	Body   string
+}
+
+// ScriptedServer answers the nth request with the nth scripted response,
+// repeating the last one once the script is exhausted. It is useful for
+// exercising retries and other multi-request behavior.
+type ScriptedServer struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	responses []ScriptedResponse
+	requests  []*RecordedRequest
+}
+
+func NewScriptedServer(ssl bool, responses ...ScriptedResponse) (*ScriptedServer, error) {
+	if len(responses) == 0 {
+		return nil, fmt.Errorf("ScriptedServer needs at least one response")
+	}
+
+	bodies := make([][]byte, len(responses))
+	for i, response := range responses {
+		b, err := json.Marshal(response.Body)
+		if err != nil {
+			return nil, err
+		}
+		bodies[i] = b
+	}
+
+	s := &ScriptedServer{responses: responses}
+
+	f := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		s.mu.Lock()
+		i := len(s.requests)
+		s.requests = append(s.requests, &RecordedRequest{
+			Method: r.Method,
+			Url:    r.RequestURI,
+			Header: r.Header.Clone(),
+			Body:   string(body),
+		})
+		s.mu.Unlock()
+
+		if i >= len(s.responses) {
+			i = len(s.responses) - 1
+		}
+
+		response := s.responses[i]
+
+		w.Header().Set("Content-Type", "application/json")
+		for key, value := range response.Headers {
+			w.Header().Set(key, value)
+		}
+
+		w.WriteHeader(response.Status)
+
+		fmt.Fprintln(w, string(bodies[i]))
+	})
+
+	if ssl {
+		s.Server = httptest.NewTLSServer(f)
+	} else {
+		s.Server = httptest.NewServer(f)
+	}
+
+	return s, nil
+}
+
+// Requests returns the requests received so far, in order.
+func (s *ScriptedServer) Requests() []*RecordedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	requests := make([]*RecordedRequest, len(s.requests))
+	copy(requests, s.requests)
+
+	return requests
+}
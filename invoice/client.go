@@ -1,83 +1,139 @@
 package invoice
 
 import (
+	"context"
 	"github.com/Invoiced/invoiced-go/v2"
+	"io"
 	"strconv"
+	"time"
 )
 
 type Client struct {
 	*invoiced.Api
 }
 
+func (c *Client) resource() invoiced.Resource[invoiced.Invoice, invoiced.InvoiceRequest, int64] {
+	return invoiced.NewResource[invoiced.Invoice, invoiced.InvoiceRequest, int64](c.Api, "invoice", "/invoices")
+}
+
 func (c *Client) Create(request *invoiced.InvoiceRequest) (*invoiced.Invoice, error) {
-	resp := new(invoiced.Invoice)
-	err := c.Api.Create("/invoices", request, resp)
-	return resp, err
+	return c.CreateCtx(context.Background(), request)
+}
+
+func (c *Client) CreateCtx(ctx context.Context, request *invoiced.InvoiceRequest) (*invoiced.Invoice, error) {
+	return c.resource().CreateCtx(ctx, request)
 }
 
 func (c *Client) Retrieve(id int64) (*invoiced.Invoice, error) {
-	resp := new(invoiced.Invoice)
-	_, err := c.Api.Get("/invoices/"+strconv.FormatInt(id, 10), resp)
-	return resp, err
+	return c.RetrieveCtx(context.Background(), id)
+}
+
+func (c *Client) RetrieveCtx(ctx context.Context, id int64) (*invoiced.Invoice, error) {
+	return c.resource().RetrieveCtx(ctx, id)
 }
 
 func (c *Client) RetrieveAccountingSyncStatus(id int64) (*invoiced.AccountingSyncStatus, error) {
+	return c.RetrieveAccountingSyncStatusCtx(context.Background(), id)
+}
+
+func (c *Client) RetrieveAccountingSyncStatusCtx(ctx context.Context, id int64) (_ *invoiced.AccountingSyncStatus, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "invoice.retrieve_accounting_sync_status")
+	defer func() { op.End(err) }()
+
 	resp := new(invoiced.AccountingSyncStatus)
-	_, err := c.Api.Get("/invoices/"+strconv.FormatInt(id, 10)+"/accounting_sync_status", resp)
+	_, err = c.Api.GetCtx(ctx, "/invoices/"+strconv.FormatInt(id, 10)+"/accounting_sync_status", resp)
 	return resp, err
 }
 
 func (c *Client) Update(id int64, request *invoiced.InvoiceRequest) (*invoiced.Invoice, error) {
-	resp := new(invoiced.Invoice)
-	err := c.Api.Update("/invoices/"+strconv.FormatInt(id, 10), request, resp)
-	return resp, err
+	return c.UpdateCtx(context.Background(), id, request)
+}
+
+func (c *Client) UpdateCtx(ctx context.Context, id int64, request *invoiced.InvoiceRequest) (*invoiced.Invoice, error) {
+	return c.resource().UpdateCtx(ctx, id, request)
 }
 
 func (c *Client) Void(id int64) (*invoiced.Invoice, error) {
+	return c.VoidCtx(context.Background(), id)
+}
+
+func (c *Client) VoidCtx(ctx context.Context, id int64) (_ *invoiced.Invoice, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "invoice.void")
+	defer func() { op.End(err) }()
+
 	resp := new(invoiced.Invoice)
-	err := c.Api.PostWithoutData("/invoices/"+strconv.FormatInt(id, 10)+"/void", resp)
+	err = c.Api.PostWithoutDataCtx(ctx, "/invoices/"+strconv.FormatInt(id, 10)+"/void", resp)
 	return resp, err
 }
 
 func (c *Client) Delete(id int64) error {
-	return c.Api.Delete("/invoices/" + strconv.FormatInt(id, 10))
+	return c.DeleteCtx(context.Background(), id)
+}
+
+func (c *Client) DeleteCtx(ctx context.Context, id int64) error {
+	return c.resource().DeleteCtx(ctx, id)
 }
 
 func (c *Client) Count() (int64, error) {
-	return c.Api.Count("/invoices")
+	return c.CountCtx(context.Background())
+}
+
+func (c *Client) CountCtx(ctx context.Context) (int64, error) {
+	return c.resource().CountCtx(ctx)
 }
 
 func (c *Client) ListAll(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Invoices, error) {
-	return c.ListAllHelper(invoiced.AddFilterAndSort("/invoices", filter, sort), filter, sort)
+	return c.ListAllCtx(context.Background(), filter, sort)
 }
 
-func (c *Client) ListAllHelper(endpoint string, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Invoices, error) {
-	invoices := make(invoiced.Invoices, 0)
-NEXT:
+func (c *Client) ListAllCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Invoices, error) {
+	return c.resource().ListAllCtx(ctx, filter, sort)
+}
 
-	tmpInvoices, endpoint, err := c.ListHelper(endpoint, filter, sort)
+func (c *Client) Iterate(filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.Invoice] {
+	return c.IterateCtx(context.Background(), filter, sort)
+}
 
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) IterateCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.Invoice] {
+	return c.resource().IterateCtx(ctx, filter, sort)
+}
+
+func (c *Client) ListAllHelper(endpoint string, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Invoices, error) {
+	return c.ListAllHelperCtx(context.Background(), endpoint, filter, sort)
+}
 
-	invoices = append(invoices, tmpInvoices...)
+func (c *Client) ListAllHelperCtx(ctx context.Context, endpoint string, filter *invoiced.Filter, sort *invoiced.Sort) (_ invoiced.Invoices, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "invoice.list_all_helper")
+	defer func() { op.End(err) }()
 
-	if endpoint != "" {
-		goto NEXT
+	if len(endpoint) == 0 {
+		endpoint = invoiced.AddFilterAndSort("/invoices", filter, sort)
 	}
 
-	return invoices, nil
+	return c.listAll(ctx, endpoint)
+}
+
+// listAll is the uninstrumented body of the list methods, which start their
+// own operation.
+func (c *Client) listAll(ctx context.Context, endpoint string) (invoiced.Invoices, error) {
+	return invoiced.CollectAll[invoiced.Invoices](ctx, c.Api, endpoint)
 }
 
 func (c *Client) ListHelper(url string, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Invoices, string, error) {
+	return c.ListHelperCtx(context.Background(), url, filter, sort)
+}
+
+func (c *Client) ListHelperCtx(ctx context.Context, url string, filter *invoiced.Filter, sort *invoiced.Sort) (_ invoiced.Invoices, _ string, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "invoice.list_helper")
+	defer func() { op.End(err) }()
+
 	if len(url) == 0 {
 		url = invoiced.AddFilterAndSort("/invoices", filter, sort)
 	}
 
 	invoices := make(invoiced.Invoices, 0)
 
-	nextEndpoint, err := c.Api.Get(url, &invoices)
+	nextEndpoint, err := c.Api.GetCtx(ctx, url, &invoices)
 	if err != nil {
 		return nil, "", err
 	}
@@ -85,15 +141,45 @@ func (c *Client) ListHelper(url string, filter *invoiced.Filter, sort *invoiced.
 	return invoices, nextEndpoint, nil
 }
 
+// ListAllInvoicesStartDate is a shorthand for ListAllQuery with
+// Query.StartDate, which also combines the date with other parameters.
 func (c *Client) ListAllInvoicesStartDate(filter *invoiced.Filter, sort *invoiced.Sort, invoiceDate int64) (invoiced.Invoices, error) {
-	return c.ListAllInvoicesStartEndDate(filter, sort, invoiceDate, 0)
+	return c.ListAllInvoicesStartDateCtx(context.Background(), filter, sort, invoiceDate)
 }
 
+func (c *Client) ListAllInvoicesStartDateCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort, invoiceDate int64) (_ invoiced.Invoices, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "invoice.list_all_invoices_start_date")
+	defer func() { op.End(err) }()
+
+	return c.listAll(ctx, startEndDateEndpoint(filter, sort, invoiceDate, 0))
+}
+
+// ListAllInvoicesEndDate is a shorthand for ListAllQuery with Query.EndDate.
 func (c *Client) ListAllInvoicesEndDate(filter *invoiced.Filter, sort *invoiced.Sort, invoiceDate int64) (invoiced.Invoices, error) {
-	return c.ListAllInvoicesStartEndDate(filter, sort, 0, invoiceDate)
+	return c.ListAllInvoicesEndDateCtx(context.Background(), filter, sort, invoiceDate)
 }
 
+func (c *Client) ListAllInvoicesEndDateCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort, invoiceDate int64) (_ invoiced.Invoices, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "invoice.list_all_invoices_end_date")
+	defer func() { op.End(err) }()
+
+	return c.listAll(ctx, startEndDateEndpoint(filter, sort, 0, invoiceDate))
+}
+
+// ListAllInvoicesStartEndDate is a shorthand for ListAllQuery with
+// Query.StartDate and Query.EndDate.
 func (c *Client) ListAllInvoicesStartEndDate(filter *invoiced.Filter, sort *invoiced.Sort, startDate, endDate int64) (invoiced.Invoices, error) {
+	return c.ListAllInvoicesStartEndDateCtx(context.Background(), filter, sort, startDate, endDate)
+}
+
+func (c *Client) ListAllInvoicesStartEndDateCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort, startDate, endDate int64) (_ invoiced.Invoices, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "invoice.list_all_invoices_start_end_date")
+	defer func() { op.End(err) }()
+
+	return c.listAll(ctx, startEndDateEndpoint(filter, sort, startDate, endDate))
+}
+
+func startEndDateEndpoint(filter *invoiced.Filter, sort *invoiced.Sort, startDate, endDate int64) string {
 	url := "/invoices"
 	url = invoiced.AddFilterAndSort(url, filter, sort)
 
@@ -107,10 +193,29 @@ func (c *Client) ListAllInvoicesStartEndDate(filter *invoiced.Filter, sort *invo
 		url = invoiced.AddQueryParameter(url, "end_date", endDateString)
 	}
 
-	return c.ListAllHelper(url, filter, sort)
+	return url
+}
+
+// ListAllInvoicesStartEndTime is a shorthand for ListAllQuery with
+// Query.DateRange.
+func (c *Client) ListAllInvoicesStartEndTime(filter *invoiced.Filter, sort *invoiced.Sort, start, end time.Time) (invoiced.Invoices, error) {
+	return c.ListAllInvoicesStartEndTimeCtx(context.Background(), filter, sort, start, end)
+}
+
+func (c *Client) ListAllInvoicesStartEndTimeCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort, start, end time.Time) (invoiced.Invoices, error) {
+	return c.ListAllInvoicesStartEndDateCtx(ctx, filter, sort, invoiced.Unix(start), invoiced.Unix(end))
 }
 
+// ListAllInvoicesUpdatedDate is a shorthand for ListAllQuery with
+// Query.UpdatedAfter.
 func (c *Client) ListAllInvoicesUpdatedDate(filter *invoiced.Filter, sort *invoiced.Sort, invoiceDate int64) (invoiced.Invoices, error) {
+	return c.ListAllInvoicesUpdatedDateCtx(context.Background(), filter, sort, invoiceDate)
+}
+
+func (c *Client) ListAllInvoicesUpdatedDateCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort, invoiceDate int64) (_ invoiced.Invoices, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "invoice.list_all_invoices_updated_date")
+	defer func() { op.End(err) }()
+
 	url := "/invoices"
 	url = invoiced.AddFilterAndSort(url, filter, sort)
 
@@ -119,21 +224,56 @@ func (c *Client) ListAllInvoicesUpdatedDate(filter *invoiced.Filter, sort *invoi
 		url = invoiced.AddQueryParameter(url, "updated_after", updatedAfterString)
 	}
 
-	return c.ListAllHelper(url, filter, sort)
+	return c.listAll(ctx, url)
 }
 
 func (c *Client) List(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Invoices, string, error) {
-	return c.ListHelper("", filter, sort)
+	return c.ListCtx(context.Background(), filter, sort)
+}
+
+func (c *Client) ListCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Invoices, string, error) {
+	return c.resource().ListCtx(ctx, filter, sort)
+}
+
+func (c *Client) ListQuery(query *invoiced.Query) (invoiced.Invoices, string, error) {
+	return c.ListQueryCtx(context.Background(), query)
+}
+
+func (c *Client) ListQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.Invoices, string, error) {
+	return c.resource().ListQueryCtx(ctx, query)
+}
+
+func (c *Client) ListAllQuery(query *invoiced.Query) (invoiced.Invoices, error) {
+	return c.ListAllQueryCtx(context.Background(), query)
+}
+
+func (c *Client) ListAllQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.Invoices, error) {
+	return c.resource().ListAllQueryCtx(ctx, query)
+}
+
+func (c *Client) IterateQuery(query *invoiced.Query) *invoiced.Iter[*invoiced.Invoice] {
+	return c.IterateQueryCtx(context.Background(), query)
+}
+
+func (c *Client) IterateQueryCtx(ctx context.Context, query *invoiced.Query) *invoiced.Iter[*invoiced.Invoice] {
+	return c.resource().IterateQueryCtx(ctx, query)
 }
 
 func (c *Client) ListInvoiceByNumber(invoiceNumber string) (*invoiced.Invoice, error) {
+	return c.ListInvoiceByNumberCtx(context.Background(), invoiceNumber)
+}
+
+func (c *Client) ListInvoiceByNumberCtx(ctx context.Context, invoiceNumber string) (_ *invoiced.Invoice, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "invoice.list_invoice_by_number")
+	defer func() { op.End(err) }()
+
 	filter := invoiced.NewFilter()
-	err := filter.Set("number", invoiceNumber)
+	err = filter.Set("number", invoiceNumber)
 	if err != nil {
 		return nil, err
 	}
 
-	invoices, apiError := c.ListAll(filter, nil)
+	invoices, apiError := c.listAll(ctx, invoiced.AddFilterAndSort("/invoices", filter, nil))
 
 	if apiError != nil {
 		return nil, apiError
@@ -147,84 +287,154 @@ func (c *Client) ListInvoiceByNumber(invoiceNumber string) (*invoiced.Invoice, e
 }
 
 func (c *Client) SendEmail(id int64, request *invoiced.SendEmailRequest) error {
-	return c.Api.Create("/invoices/"+strconv.FormatInt(id, 10)+"/emails", request, nil)
+	return c.SendEmailCtx(context.Background(), id, request)
+}
+
+func (c *Client) SendEmailCtx(ctx context.Context, id int64, request *invoiced.SendEmailRequest) (err error) {
+	ctx, op := c.Api.StartOperation(ctx, "invoice.send_email")
+	defer func() { op.End(err) }()
+
+	return c.Api.CreateCtx(ctx, "/invoices/"+strconv.FormatInt(id, 10)+"/emails", request, nil)
 }
 
 func (c *Client) SendText(id int64, request *invoiced.SendTextMessageRequest) (invoiced.TextMessages, error) {
+	return c.SendTextCtx(context.Background(), id, request)
+}
+
+func (c *Client) SendTextCtx(ctx context.Context, id int64, request *invoiced.SendTextMessageRequest) (_ invoiced.TextMessages, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "invoice.send_text")
+	defer func() { op.End(err) }()
+
 	resp := new(invoiced.TextMessages)
-	err := c.Api.Create("/invoices/"+strconv.FormatInt(id, 10)+"/text_messages", request, resp)
+	err = c.Api.CreateCtx(ctx, "/invoices/"+strconv.FormatInt(id, 10)+"/text_messages", request, resp)
 	return *resp, err
 }
 
 func (c *Client) SendLetter(id int64) (*invoiced.Letter, error) {
+	return c.SendLetterCtx(context.Background(), id)
+}
+
+func (c *Client) SendLetterCtx(ctx context.Context, id int64) (_ *invoiced.Letter, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "invoice.send_letter")
+	defer func() { op.End(err) }()
+
 	resp := new(invoiced.Letter)
-	err := c.Api.Create("/invoices/"+strconv.FormatInt(id, 10)+"/letters", nil, resp)
+	err = c.Api.CreateCtx(ctx, "/invoices/"+strconv.FormatInt(id, 10)+"/letters", nil, resp)
 	return resp, err
 }
 
 func (c *Client) Pay(id int64) (*invoiced.Invoice, error) {
+	return c.PayCtx(context.Background(), id)
+}
+
+func (c *Client) PayCtx(ctx context.Context, id int64) (_ *invoiced.Invoice, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "invoice.pay")
+	defer func() { op.End(err) }()
+
 	resp := new(invoiced.Invoice)
-	err := c.Api.Create("/invoices/"+strconv.FormatInt(id, 10)+"/pay", nil, resp)
+	err = c.Api.CreateCtx(ctx, "/invoices/"+strconv.FormatInt(id, 10)+"/pay", nil, resp)
 	return resp, err
 }
 
 func (c *Client) ListAttachments(id int64) (invoiced.Files, error) {
-	endpoint := "/invoices/" + strconv.FormatInt(id, 10) + "/attachments"
+	return c.ListAttachmentsCtx(context.Background(), id)
+}
 
-	files := make(invoiced.Files, 0)
+func (c *Client) ListAttachmentsCtx(ctx context.Context, id int64) (invoiced.Files, error) {
+	return c.ListAttachmentsQueryCtx(ctx, id, nil)
+}
+
+// ListAttachmentsQuery is like ListAttachments, with the parameters of a Query.
+func (c *Client) ListAttachmentsQuery(id int64, query *invoiced.Query) (invoiced.Files, error) {
+	return c.ListAttachmentsQueryCtx(context.Background(), id, query)
+}
 
-NEXT:
-	tempFiles := make(invoiced.Files, 0)
+func (c *Client) ListAttachmentsQueryCtx(ctx context.Context, id int64, query *invoiced.Query) (_ invoiced.Files, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "invoice.list_attachments")
+	defer func() { op.End(err) }()
 
-	endpoint, err := c.Api.Get(endpoint, &tempFiles)
+	endpoint, err := query.Endpoint("/invoices/" + strconv.FormatInt(id, 10) + "/attachments")
 	if err != nil {
 		return nil, err
 	}
 
-	files = append(files, tempFiles...)
-
-	if endpoint != "" {
-		goto NEXT
-	}
-
-	return files, nil
+	return invoiced.CollectAll[invoiced.Files](ctx, c.Api, endpoint)
 }
 
 func (c *Client) RetrieveNotes(id int64) (invoiced.Notes, error) {
-	endpoint := "/invoices/" + strconv.FormatInt(id, 10) + "/notes"
+	return c.RetrieveNotesCtx(context.Background(), id)
+}
 
-	notes := make(invoiced.Notes, 0)
+func (c *Client) RetrieveNotesCtx(ctx context.Context, id int64) (invoiced.Notes, error) {
+	return c.RetrieveNotesQueryCtx(ctx, id, nil)
+}
 
-NEXT:
-	tmpNotes := make(invoiced.Notes, 0)
+// RetrieveNotesQuery is like RetrieveNotes, with the parameters of a Query.
+func (c *Client) RetrieveNotesQuery(id int64, query *invoiced.Query) (invoiced.Notes, error) {
+	return c.RetrieveNotesQueryCtx(context.Background(), id, query)
+}
 
-	endpoint, err := c.Api.Get(endpoint, &tmpNotes)
+func (c *Client) RetrieveNotesQueryCtx(ctx context.Context, id int64, query *invoiced.Query) (_ invoiced.Notes, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "invoice.retrieve_notes")
+	defer func() { op.End(err) }()
 
+	endpoint, err := query.Endpoint("/invoices/" + strconv.FormatInt(id, 10) + "/notes")
 	if err != nil {
 		return nil, err
 	}
 
-	notes = append(notes, tmpNotes...)
-
-	if endpoint != "" {
-		goto NEXT
-	}
-
-	return notes, nil
+	return invoiced.CollectAll[invoiced.Notes](ctx, c.Api, endpoint)
 }
 
 func (c *Client) CreatePaymentPlan(id int64, request *invoiced.PaymentPlanRequest) (*invoiced.PaymentPlan, error) {
+	return c.CreatePaymentPlanCtx(context.Background(), id, request)
+}
+
+func (c *Client) CreatePaymentPlanCtx(ctx context.Context, id int64, request *invoiced.PaymentPlanRequest) (_ *invoiced.PaymentPlan, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "invoice.create_payment_plan")
+	defer func() { op.End(err) }()
+
 	resp := new(invoiced.PaymentPlan)
-	err := c.Api.Create("/invoices/"+strconv.FormatInt(id, 10)+"/payment_plan", request, resp)
+	err = c.Api.CreateCtx(ctx, "/invoices/"+strconv.FormatInt(id, 10)+"/payment_plan", request, resp)
 	return resp, err
 }
 
 func (c *Client) RetrievePaymentPlan(id int64) (*invoiced.PaymentPlan, error) {
+	return c.RetrievePaymentPlanCtx(context.Background(), id)
+}
+
+func (c *Client) RetrievePaymentPlanCtx(ctx context.Context, id int64) (_ *invoiced.PaymentPlan, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "invoice.retrieve_payment_plan")
+	defer func() { op.End(err) }()
+
 	resp := new(invoiced.PaymentPlan)
-	_, err := c.Api.Get("/invoices/"+strconv.FormatInt(id, 10)+"/payment_plan", resp)
+	_, err = c.Api.GetCtx(ctx, "/invoices/"+strconv.FormatInt(id, 10)+"/payment_plan", resp)
 	return resp, err
 }
 
 func (c *Client) CancelPaymentPlan(id int64) error {
-	return c.Api.Delete("/invoices/" + strconv.FormatInt(id, 10) + "/payment_plan")
+	return c.CancelPaymentPlanCtx(context.Background(), id)
+}
+
+func (c *Client) CancelPaymentPlanCtx(ctx context.Context, id int64) (err error) {
+	ctx, op := c.Api.StartOperation(ctx, "invoice.cancel_payment_plan")
+	defer func() { op.End(err) }()
+
+	return c.Api.DeleteCtx(ctx, "/invoices/"+strconv.FormatInt(id, 10)+"/payment_plan")
+}
+
+func (c *Client) DownloadPDF(id int64, w io.Writer) (*invoiced.Download, error) {
+	return c.DownloadPDFCtx(context.Background(), id, w)
+}
+
+func (c *Client) DownloadPDFCtx(ctx context.Context, id int64, w io.Writer) (_ *invoiced.Download, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "invoice.download_pdf")
+	defer func() { op.End(err) }()
+
+	invoice := new(invoiced.Invoice)
+	if _, err = c.Api.GetCtx(ctx, c.resource().Endpoint(id), invoice); err != nil {
+		return nil, err
+	}
+
+	return c.Api.DownloadCtx(ctx, invoice.PdfUrl, w)
 }
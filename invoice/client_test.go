@@ -1,7 +1,10 @@
 package invoice
 
 import (
+	"bytes"
 	"github.com/Invoiced/invoiced-go/v2"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"strconv"
 	"testing"
@@ -540,3 +543,129 @@ func TestInvoice_CancelPaymentPlan(t *testing.T) {
 		t.Fatal("Error occurred during deletion")
 	}
 }
+
+func TestInvoice_ListAllInstrumentation(t *testing.T) {
+	key := "test api key"
+
+	mockListResponse := invoiced.Invoices{{Id: 1234, Name: "nomenclature"}}
+
+	server, err := invdmockserver.New(200, mockListResponse, "json", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	recorder := invoiced.NewMemoryRecorder()
+	client := Client{invoiced.NewMockApi(key, server, invoiced.WithInstrumentation(recorder))}
+
+	_, err = client.ListAll(nil, nil)
+	if err != nil {
+		t.Fatal("Error listing entity", err)
+	}
+
+	if n := recorder.Counter(invoiced.MetricOperations, invoiced.Attr("resource", "invoice"), invoiced.Attr("operation", "list_all"), invoiced.Attr("outcome", "ok")); n != 1 {
+		t.Fatal("Expected invoice.list_all to be recorded once, got", n)
+	}
+
+	if recorder.Spans()[0].Name != "invoice.list_all" {
+		t.Fatal("Unexpected first span", recorder.Spans()[0].Name)
+	}
+}
+
+func TestInvoice_DownloadPDF(t *testing.T) {
+	pdfServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		_, _ = w.Write([]byte("%PDF-1.4 invoice"))
+	}))
+	defer pdfServer.Close()
+
+	server, err := invdmockserver.NewScriptedServer(true, invdmockserver.ScriptedResponse{
+		Status: 200,
+		Body:   &invoiced.Invoice{Id: 1234, PdfUrl: pdfServer.URL + "/invoices/abc/pdf"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	client := Client{invoiced.NewMockApi("test api key", server.Server)}
+
+	var buf bytes.Buffer
+	download, err := client.DownloadPDF(1234, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if buf.String() != "%PDF-1.4 invoice" || download.Size != int64(buf.Len()) || len(download.SHA256) != 64 {
+		t.Fatal("Unexpected download", download, buf.String())
+	}
+
+	if server.Requests()[0].Url != "/invoices/1234" {
+		t.Fatal("Unexpected request", server.Requests()[0].Url)
+	}
+}
+
+func TestInvoice_ListAllInvoicesStartEndTime(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(true, invdmockserver.ScriptedResponse{Status: 200, Body: invoiced.Invoices{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	client := Client{invoiced.NewMockApi("test api key", server.Server)}
+
+	start, end := invoiced.DayRange(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC), time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC), time.UTC)
+
+	if _, err := client.ListAllInvoicesStartEndTime(nil, nil, start, end); err != nil {
+		t.Fatal(err)
+	}
+
+	if url := server.Requests()[0].Url; url != "/invoices?start_date=1709251200&end_date=1711929599" {
+		t.Fatal("Unexpected request", url)
+	}
+}
+
+func TestInvoice_ListAttachmentsQuery(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(true, invdmockserver.ScriptedResponse{Status: 200, Body: invoiced.Files{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	client := Client{invoiced.NewMockApi("test api key", server.Server)}
+
+	if _, err := client.ListAttachmentsQuery(1234, invoiced.NewQuery().Sort("created_at", invoiced.DESC).PerPage(10)); err != nil {
+		t.Fatal(err)
+	}
+
+	if url := server.Requests()[0].Url; url != "/invoices/1234/attachments?per_page=10&sort=created_at+DESC" {
+		t.Fatal("Unexpected request", url)
+	}
+}
+
+func TestInvoice_ListAllInvoicesStartDateInstrumentation(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(true, invdmockserver.ScriptedResponse{Status: 200, Body: invoiced.Invoices{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	recorder := invoiced.NewMemoryRecorder()
+	client := Client{invoiced.NewMockApi("test api key", server.Server, invoiced.WithInstrumentation(recorder))}
+
+	if _, err := client.ListAllInvoicesStartDate(nil, nil, 1704067200); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := recorder.Counter(invoiced.MetricOperations); n != 1 {
+		t.Fatal("Expected a single operation to be recorded, got", n)
+	}
+
+	if n := len(recorder.Latencies(invoiced.MetricOperationDuration)); n != 1 {
+		t.Fatal("Expected a single operation duration, got", n)
+	}
+
+	if name := recorder.Spans()[0].Name; name != "invoice.list_all_invoices_start_date" {
+		t.Fatal("Unexpected span", name)
+	}
+}
@@ -2,7 +2,7 @@ package invoiced
 
 import (
 	"encoding/json"
-	"strconv"
+	"time"
 )
 
 type InvoiceRequest struct {
@@ -31,45 +31,74 @@ type InvoiceRequest struct {
 	Taxes                  []*TaxRequest           `json:"taxes,omitempty"`
 }
 
+func (r *InvoiceRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+
+	var f fieldErrors
+	r.validate(&f)
+
+	return f.err()
+}
+
+// ValidateCreate also requires the customer.
+func (r *InvoiceRequest) ValidateCreate() error {
+	if r == nil {
+		return nil
+	}
+
+	var f fieldErrors
+	f.required("customer", r.Customer != nil)
+	r.validate(&f)
+
+	return f.err()
+}
+
+func (r *InvoiceRequest) validate(f *fieldErrors) {
+	f.id("customer", r.Customer)
+	f.currency("currency", r.Currency)
+	f.notBefore("due_date", r.DueDate, "date", r.Date)
+	f.lineItems("items", r.Items)
+}
+
 type Invoice struct {
-	Attachments            []int64                `json:"attachments"`
-	AttemptCount           int64                  `json:"attempt_count"`
-	AutoPay                bool                   `json:"autopay"`
-	Balance                float64                `json:"balance"`
-	Closed                 bool                   `json:"closed"`
-	CreatedAt              int64                  `json:"created_at"`
-	Currency               string                 `json:"currency"`
-	Customer               int64                  `json:"-"`
-	CustomerFull           *Customer              `json:"-"`
-	CustomerRaw            json.RawMessage        `json:"customer"`
-	Date                   int64                  `json:"date"`
-	DisabledPaymentMethods []string               `json:"disabled_payment_methods"`
-	Discounts              []Discount             `json:"discounts"`
-	Draft                  bool                   `json:"draft"`
-	DueDate                int64                  `json:"due_date"`
-	Id                     int64                  `json:"id"`
-	Items                  []LineItem             `json:"items"`
-	Metadata               map[string]interface{} `json:"metadata"`
-	Name                   string                 `json:"name"`
-	NextPaymentAttempt     int64                  `json:"next_payment_attempt"`
-	Notes                  string                 `json:"notes"`
-	Number                 string                 `json:"number"`
-	Object                 string                 `json:"object"`
-	Paid                   bool                   `json:"paid"`
-	PaymentPlan            int64                  `json:"payment_plan"`
-	PaymentTerms           string                 `json:"payment_terms"`
-	PaymentUrl             string                 `json:"payment_url"`
-	PdfUrl                 string                 `json:"pdf_url"`
-	PurchaseOrder          string                 `json:"purchase_order"`
-	Sent                   bool                   `json:"sent"`
-	ShipTo                 *ShippingDetail        `json:"ship_to"`
-	Status                 string                 `json:"status"`
-	Subscription           int64                  `json:"subscription"`
-	Subtotal               float64                `json:"subtotal"`
-	Taxes                  []Tax                  `json:"taxes"`
-	Total                  float64                `json:"total"`
-	UpdatedAt              int64                  `json:"updated_at"`
-	Url                    string                 `json:"url"`
+	Attachments            []int64                         `json:"attachments"`
+	AttemptCount           int64                           `json:"attempt_count"`
+	AutoPay                bool                            `json:"autopay"`
+	Balance                float64                         `json:"balance"`
+	Closed                 bool                            `json:"closed"`
+	CreatedAt              int64                           `json:"created_at"`
+	Currency               string                          `json:"currency"`
+	Customer               Expandable[Customer, int64]     `json:"customer"`
+	Date                   int64                           `json:"date"`
+	DisabledPaymentMethods []string                        `json:"disabled_payment_methods"`
+	Discounts              []Discount                      `json:"discounts"`
+	Draft                  bool                            `json:"draft"`
+	DueDate                int64                           `json:"due_date"`
+	Id                     int64                           `json:"id"`
+	Items                  []LineItem                      `json:"items"`
+	Metadata               map[string]interface{}          `json:"metadata"`
+	Name                   string                          `json:"name"`
+	NextPaymentAttempt     int64                           `json:"next_payment_attempt"`
+	Notes                  string                          `json:"notes"`
+	Number                 string                          `json:"number"`
+	Object                 string                          `json:"object"`
+	Paid                   bool                            `json:"paid"`
+	PaymentPlan            int64                           `json:"payment_plan"`
+	PaymentTerms           PaymentTerms                    `json:"payment_terms"`
+	PaymentUrl             string                          `json:"payment_url"`
+	PdfUrl                 string                          `json:"pdf_url"`
+	PurchaseOrder          string                          `json:"purchase_order"`
+	Sent                   bool                            `json:"sent"`
+	ShipTo                 *ShippingDetail                 `json:"ship_to"`
+	Status                 InvoiceStatus                   `json:"status"`
+	Subscription           Expandable[Subscription, int64] `json:"subscription"`
+	Subtotal               float64                         `json:"subtotal"`
+	Taxes                  []Tax                           `json:"taxes"`
+	Total                  float64                         `json:"total"`
+	UpdatedAt              int64                           `json:"updated_at"`
+	Url                    string                          `json:"url"`
 }
 
 type Invoices []*Invoice
@@ -110,36 +139,50 @@ func (i *Invoice) TotalDiscountAmount() float64 {
 	return totalDiscount
 }
 
-func (i *Invoice) UnmarshalJSON(data []byte) error {
-	type invoice2 Invoice
-	if err := json.Unmarshal(data, (*invoice2)(i)); err != nil {
-		return err
-	}
+func (i *Invoice) TotalMoney() (Money, error) {
+	return CheckedMoneyFromFloat(i.Total, i.Currency)
+}
 
-	rj := i.CustomerRaw
+func (i *Invoice) SubtotalMoney() (Money, error) {
+	return CheckedMoneyFromFloat(i.Subtotal, i.Currency)
+}
+
+func (i *Invoice) BalanceMoney() (Money, error) {
+	return CheckedMoneyFromFloat(i.Balance, i.Currency)
+}
 
-	i.Customer, _ = strconv.ParseInt(string(rj), 10, 64)
-	customer := new(Customer)
+// TotalTaxMoney is the exact form of TotalTaxAmount.
+func (i *Invoice) TotalTaxMoney() (Money, error) {
+	var amounts []float64
 
-	err := json.Unmarshal(rj, customer)
+	for _, lineItem := range i.Items {
+		for _, lineItemTax := range lineItem.Taxes {
+			amounts = append(amounts, lineItemTax.Amount)
+		}
+	}
 
-	if err == nil {
-		i.CustomerFull = customer
-		i.Customer = customer.Id
+	for _, invoiceTax := range i.Taxes {
+		amounts = append(amounts, invoiceTax.Amount)
 	}
 
-	return nil
+	return sumMoney(i.Currency, amounts)
 }
 
-func (i *Invoice) MarshalJSON() ([]byte, error) {
-	type invoice2 Invoice
-	i2 := (*invoice2)(i)
+// TotalDiscountMoney is the exact form of TotalDiscountAmount.
+func (i *Invoice) TotalDiscountMoney() (Money, error) {
+	var amounts []float64
 
-	if i2.Customer > 0 {
-		i2.CustomerRaw = []byte(strconv.FormatInt(i2.Customer, 10))
+	for _, lineItem := range i.Items {
+		for _, lineItemDiscount := range lineItem.Discounts {
+			amounts = append(amounts, lineItemDiscount.Amount)
+		}
 	}
 
-	return json.Marshal(i2)
+	for _, invoiceDiscount := range i.Discounts {
+		amounts = append(amounts, invoiceDiscount.Amount)
+	}
+
+	return sumMoney(i.Currency, amounts)
 }
 
 func (i *Invoice) String() string {
@@ -147,3 +190,23 @@ func (i *Invoice) String() string {
 
 	return string(b)
 }
+
+func (i *Invoice) CreatedAtTime() time.Time {
+	return Time(i.CreatedAt)
+}
+
+func (i *Invoice) DateTime() time.Time {
+	return Time(i.Date)
+}
+
+func (i *Invoice) DueDateTime() time.Time {
+	return Time(i.DueDate)
+}
+
+func (i *Invoice) NextPaymentAttemptTime() time.Time {
+	return Time(i.NextPaymentAttempt)
+}
+
+func (i *Invoice) UpdatedAtTime() time.Time {
+	return Time(i.UpdatedAt)
+}
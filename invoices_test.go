@@ -170,7 +170,7 @@ func TestUnMarshalInvoiceObject(t *testing.T) {
 		t.Fatal("Id is incorrect")
 	}
 
-	if so.Customer != 15444 {
+	if so.Customer.Id != 15444 {
 		t.Fatal("Customer is incorrect")
 	}
 
@@ -1,52 +1,103 @@
 package item
 
-import "github.com/Invoiced/invoiced-go/v2"
+import (
+	"context"
+
+	"github.com/Invoiced/invoiced-go/v2"
+)
 
 type Client struct {
 	*invoiced.Api
 }
 
+func (c *Client) resource() invoiced.Resource[invoiced.Item, invoiced.ItemRequest, string] {
+	return invoiced.NewResource[invoiced.Item, invoiced.ItemRequest, string](c.Api, "item", "/items")
+}
+
 func (c *Client) Create(request *invoiced.ItemRequest) (*invoiced.Item, error) {
-	resp := new(invoiced.Item)
-	err := c.Api.Create("/items", request, resp)
-	return resp, err
+	return c.CreateCtx(context.Background(), request)
+}
+
+func (c *Client) CreateCtx(ctx context.Context, request *invoiced.ItemRequest) (*invoiced.Item, error) {
+	return c.resource().CreateCtx(ctx, request)
 }
 
 func (c *Client) Retrieve(id string) (*invoiced.Item, error) {
-	resp := new(invoiced.Item)
-	_, err := c.Api.Get("/items/"+id, resp)
-	return resp, err
+	return c.RetrieveCtx(context.Background(), id)
+}
+
+func (c *Client) RetrieveCtx(ctx context.Context, id string) (*invoiced.Item, error) {
+	return c.resource().RetrieveCtx(ctx, id)
 }
 
 func (c *Client) Update(id string, request *invoiced.ItemRequest) (*invoiced.Item, error) {
-	resp := new(invoiced.Item)
-	err := c.Api.Update("/items/"+id, request, resp)
-	return resp, err
+	return c.UpdateCtx(context.Background(), id, request)
+}
+
+func (c *Client) UpdateCtx(ctx context.Context, id string, request *invoiced.ItemRequest) (*invoiced.Item, error) {
+	return c.resource().UpdateCtx(ctx, id, request)
 }
 
 func (c *Client) Delete(id string) error {
-	return c.Api.Delete("/items/" + id)
+	return c.DeleteCtx(context.Background(), id)
+}
+
+func (c *Client) DeleteCtx(ctx context.Context, id string) error {
+	return c.resource().DeleteCtx(ctx, id)
 }
 
 func (c *Client) ListAll(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Items, error) {
-	endpoint := invoiced.AddFilterAndSort("/items", filter, sort)
+	return c.ListAllCtx(context.Background(), filter, sort)
+}
+
+func (c *Client) ListAllCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Items, error) {
+	return c.resource().ListAllCtx(ctx, filter, sort)
+}
+
+func (c *Client) Iterate(filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.Item] {
+	return c.IterateCtx(context.Background(), filter, sort)
+}
+
+func (c *Client) IterateCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.Item] {
+	return c.resource().IterateCtx(ctx, filter, sort)
+}
 
-	items := make(invoiced.Items, 0)
+func (c *Client) List(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Items, string, error) {
+	return c.ListCtx(context.Background(), filter, sort)
+}
 
-NEXT:
-	tmpItems := make(invoiced.Items, 0)
+func (c *Client) ListCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Items, string, error) {
+	return c.resource().ListCtx(ctx, filter, sort)
+}
 
-	endpointTmp, err := c.Api.Get(endpoint, &tmpItems)
+func (c *Client) ListQuery(query *invoiced.Query) (invoiced.Items, string, error) {
+	return c.ListQueryCtx(context.Background(), query)
+}
 
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) ListQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.Items, string, error) {
+	return c.resource().ListQueryCtx(ctx, query)
+}
 
-	items = append(items, tmpItems...)
+func (c *Client) ListAllQuery(query *invoiced.Query) (invoiced.Items, error) {
+	return c.ListAllQueryCtx(context.Background(), query)
+}
 
-	if endpointTmp != "" {
-		goto NEXT
-	}
+func (c *Client) ListAllQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.Items, error) {
+	return c.resource().ListAllQueryCtx(ctx, query)
+}
+
+func (c *Client) IterateQuery(query *invoiced.Query) *invoiced.Iter[*invoiced.Item] {
+	return c.IterateQueryCtx(context.Background(), query)
+}
+
+func (c *Client) IterateQueryCtx(ctx context.Context, query *invoiced.Query) *invoiced.Iter[*invoiced.Item] {
+	return c.resource().IterateQueryCtx(ctx, query)
+}
+
+func (c *Client) Count() (int64, error) {
+	return c.CountCtx(context.Background())
+}
 
-	return items, nil
+func (c *Client) CountCtx(ctx context.Context) (int64, error) {
+	return c.resource().CountCtx(ctx)
 }
@@ -0,0 +1,182 @@
+package invoiced
+
+import (
+	"context"
+	"iter"
+	"net/http"
+)
+
+// Iter walks through every item of a list endpoint, fetching one page at a
+// time by following the pagination links returned by the API. Only the
+// current page is held in memory.
+//
+//	it := invoiced.NewIter[*invoiced.Invoice](ctx, client.Api, "/invoices")
+//	for it.Next() {
+//		invoice := it.Current()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iter[T any] struct {
+	ctx     context.Context
+	api     *Api
+	next    string
+	page    []T
+	index   int
+	current T
+	err     error
+	header  http.Header
+}
+
+// NewIter starts iterating at endpoint, which may be the first page of a
+// list or a next page endpoint saved from an earlier iteration.
+func NewIter[T any](ctx context.Context, api *Api, endpoint string) *Iter[T] {
+	return &Iter[T]{ctx: ctx, api: api, next: endpoint}
+}
+
+// Next advances to the next item, fetching the next page when needed. It
+// returns false when all items have been seen or an error occurred.
+func (it *Iter[T]) Next() bool {
+	for it.index >= len(it.page) {
+		if it.err != nil || it.next == "" {
+			return false
+		}
+
+		if !it.fetch() {
+			return false
+		}
+	}
+
+	it.current = it.page[it.index]
+	it.index++
+
+	return true
+}
+
+// NextPage fetches the next page and returns its items, skipping whatever
+// remains of the current page. It returns false at the end of the list or
+// on error.
+func (it *Iter[T]) NextPage() ([]T, bool) {
+	if it.err != nil || it.next == "" {
+		return nil, false
+	}
+
+	if !it.fetch() {
+		return nil, false
+	}
+
+	it.index = len(it.page)
+
+	return it.page, true
+}
+
+func (it *Iter[T]) fetch() bool {
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	page := make([]T, 0)
+
+	next, header, err := it.api.getPage(it.ctx, it.next, &page)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.next = next
+	it.header = header
+	it.page = page
+	it.index = 0
+
+	return true
+}
+
+// Current returns the item Next advanced to.
+func (it *Iter[T]) Current() T {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iter[T]) Err() error {
+	return it.err
+}
+
+// NextEndpoint returns the endpoint of the first page that has not been
+// fetched yet, or "" at the end of the list. Passing it to NewIter resumes
+// the iteration after the current page.
+func (it *Iter[T]) NextEndpoint() string {
+	return it.next
+}
+
+// All adapts the iterator to a range-over-func loop. An error ends the loop
+// after being yielded with the zero item.
+//
+//	for invoice, err := range it.All() {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (it *Iter[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for it.Next() {
+			if !yield(it.current, nil) {
+				return
+			}
+		}
+
+		if it.err != nil {
+			var zero T
+			yield(zero, it.err)
+		}
+	}
+}
+
+// Collect gathers the remaining items into a slice, starting with the ones
+// of the current page that Next has not advanced to yet.
+func (it *Iter[T]) Collect() ([]T, error) {
+	items := append(make([]T, 0), it.page[it.index:]...)
+	it.index = len(it.page)
+
+	for {
+		page, ok := it.NextPage()
+		if !ok {
+			break
+		}
+
+		items = append(items, page...)
+	}
+
+	if it.err != nil {
+		return nil, it.err
+	}
+
+	return items, nil
+}
+
+// Seq returns a range-over-func sequence of every item of a list endpoint.
+func Seq[T any](ctx context.Context, api *Api, endpoint string) iter.Seq2[T, error] {
+	return NewIter[T](ctx, api, endpoint).All()
+}
+
+// CollectAll fetches every page of a list endpoint into a slice of type S,
+// such as Invoices. It backs the ListAll methods of the resource clients.
+// Pages are fetched concurrently when enabled with WithConcurrentPages.
+func CollectAll[S ~[]E, E any](ctx context.Context, api *Api, endpoint string) (S, error) {
+	var items []E
+	var err error
+
+	if api.pageConcurrency > 1 {
+		items, err = collectConcurrently[E](ctx, api, endpoint)
+	} else {
+		items, err = NewIter[E](ctx, api, endpoint).Collect()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return S(items), nil
+}
@@ -0,0 +1,172 @@
+package invoiced
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Invoiced/invoiced-go/v2/invdmockserver"
+)
+
+func newPagedServer(t *testing.T) *invdmockserver.ScriptedServer {
+	server, err := invdmockserver.NewScriptedServer(true,
+		invdmockserver.ScriptedResponse{
+			Status:  200,
+			Headers: map[string]string{"Link": `</customers?page=1>; rel="self", </customers?page=2>; rel="next"`},
+			Body:    Customers{{Id: 1}, {Id: 2}},
+		},
+		invdmockserver.ScriptedResponse{
+			Status:  200,
+			Headers: map[string]string{"Link": `</customers?page=2>; rel="self", </customers?page=3>; rel="next"`},
+			Body:    Customers{{Id: 3}, {Id: 4}},
+		},
+		invdmockserver.ScriptedResponse{
+			Status:  200,
+			Headers: map[string]string{"Link": `</customers?page=3>; rel="self"`},
+			Body:    Customers{{Id: 5}},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return server
+}
+
+func TestIterWalksAllPages(t *testing.T) {
+	server := newPagedServer(t)
+	defer server.Close()
+
+	client := NewMockApi("whatever", server.Server)
+
+	it := NewIter[*Customer](context.Background(), client, "/customers")
+
+	var ids []int64
+	for it.Next() {
+		ids = append(ids, it.Current().Id)
+	}
+
+	if it.Err() != nil {
+		t.Fatal(it.Err())
+	}
+
+	if len(ids) != 5 || ids[0] != 1 || ids[4] != 5 {
+		t.Fatal("Unexpected items", ids)
+	}
+
+	if len(server.Requests()) != 3 {
+		t.Fatal("Expected 3 requests, got", len(server.Requests()))
+	}
+
+	if it.NextEndpoint() != "" {
+		t.Fatal("Expected no next endpoint, got", it.NextEndpoint())
+	}
+}
+
+func TestIterAllStopsEarly(t *testing.T) {
+	server := newPagedServer(t)
+	defer server.Close()
+
+	client := NewMockApi("whatever", server.Server)
+
+	it := NewIter[*Customer](context.Background(), client, "/customers")
+
+	for customer, err := range it.All() {
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if customer.Id == 2 {
+			break
+		}
+	}
+
+	if len(server.Requests()) != 1 {
+		t.Fatal("Expected 1 request, got", len(server.Requests()))
+	}
+
+	if it.NextEndpoint() != "/customers?page=2" {
+		t.Fatal("Unexpected next endpoint", it.NextEndpoint())
+	}
+
+	resumed, err := NewIter[*Customer](context.Background(), client, it.NextEndpoint()).Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resumed) != 3 || resumed[0].Id != 3 {
+		t.Fatal("Unexpected resumed items", resumed)
+	}
+
+	if server.Requests()[1].Url != "/customers?page=2" {
+		t.Fatal("Did not resume from the saved endpoint", server.Requests()[1].Url)
+	}
+}
+
+func TestIterCollectAfterNext(t *testing.T) {
+	server := newPagedServer(t)
+	defer server.Close()
+
+	it := NewIter[*Customer](context.Background(), NewMockApi("whatever", server.Server), "/customers")
+
+	if !it.Next() || it.Current().Id != 1 {
+		t.Fatal("Expected the first customer")
+	}
+
+	rest, err := it.Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rest) != 4 || rest[0].Id != 2 || rest[3].Id != 5 {
+		t.Fatal("Unexpected remaining items", rest)
+	}
+
+	if it.Next() {
+		t.Fatal("Expected the iteration to be over")
+	}
+}
+
+func TestIterAllYieldsError(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(true, invdmockserver.ScriptedResponse{
+		Status: 401,
+		Body:   NewAPIError("authentication_error", "bad key", ""),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	client := NewMockApi("whatever", server.Server)
+
+	var errs []error
+	for customer, err := range Seq[*Customer](context.Background(), client, "/customers") {
+		if customer != nil {
+			t.Fatal("Expected no item", customer)
+		}
+
+		errs = append(errs, err)
+	}
+
+	if len(errs) != 1 || !IsAuthentication(errs[0]) {
+		t.Fatal("Expected an authentication error, got", errs)
+	}
+}
+
+func TestCollectAllEmpty(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(true, invdmockserver.ScriptedResponse{Status: 200, Body: Customers{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	client := NewMockApi("whatever", server.Server)
+
+	customers, err := CollectAll[Customers](context.Background(), client, "/customers")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if customers == nil || len(customers) != 0 {
+		t.Fatal("Expected an empty list, got", customers)
+	}
+}
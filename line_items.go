@@ -1,5 +1,7 @@
 package invoiced
 
+import "time"
+
 type LineItemRequest struct {
 	Amount       *float64                `json:"amount,omitempty"`
 	Description  *string                 `json:"description,omitempty"`
@@ -21,40 +23,59 @@ type LineItemRequest struct {
 }
 
 type LineItem struct {
-	Amount       float64                `json:"amount"`
-	Description  string                 `json:"description"`
-	Discountable bool                   `json:"discountable"`
-	Discounts    []Discount             `json:"discounts"`
-	Id           int64                  `json:"id"`
-	Item         string                 `json:"catalog_item"`
-	Metadata     map[string]interface{} `json:"metadata"`
-	Name         string                 `json:"name"`
-	PeriodEnd    int64                  `json:"period_end"`
-	PeriodStart  int64                  `json:"period_start"`
-	Plan         string                 `json:"plan"`
-	Prorated     bool                   `json:"prorated"`
-	Quantity     float64                `json:"quantity"`
-	Taxable      bool                   `json:"taxable"`
-	Taxes        []Tax                  `json:"taxes"`
-	Type         string                 `json:"type"`
-	UnitCost     float64                `json:"unit_cost"`
+	Amount       float64                  `json:"amount"`
+	Description  string                   `json:"description"`
+	Discountable bool                     `json:"discountable"`
+	Discounts    []Discount               `json:"discounts"`
+	Id           int64                    `json:"id"`
+	Item         string                   `json:"catalog_item"`
+	Metadata     map[string]interface{}   `json:"metadata"`
+	Name         string                   `json:"name"`
+	PeriodEnd    int64                    `json:"period_end"`
+	PeriodStart  int64                    `json:"period_start"`
+	Plan         Expandable[Plan, string] `json:"plan"`
+	Prorated     bool                     `json:"prorated"`
+	Quantity     float64                  `json:"quantity"`
+	Taxable      bool                     `json:"taxable"`
+	Taxes        []Tax                    `json:"taxes"`
+	Type         string                   `json:"type"`
+	UnitCost     float64                  `json:"unit_cost"`
 }
 
 type LineItemPreview struct {
-	Amount       float64                `json:"amount"`
-	Description  string                 `json:"description"`
-	Discountable bool                   `json:"discountable"`
-	Discounts    []Discount             `json:"discounts"`
-	Item         string                 `json:"catalog_item"`
-	Metadata     map[string]interface{} `json:"metadata"`
-	Name         string                 `json:"name"`
-	PeriodEnd    int64                  `json:"period_end"`
-	PeriodStart  int64                  `json:"period_start"`
-	Plan         string                 `json:"plan"`
-	Prorated     bool                   `json:"prorated"`
-	Quantity     float64                `json:"quantity"`
-	Taxable      bool                   `json:"taxable"`
-	Taxes        []Tax                  `json:"taxes"`
-	Type         string                 `json:"type"`
-	UnitCost     float64                `json:"unit_cost"`
+	Amount       float64                  `json:"amount"`
+	Description  string                   `json:"description"`
+	Discountable bool                     `json:"discountable"`
+	Discounts    []Discount               `json:"discounts"`
+	Item         string                   `json:"catalog_item"`
+	Metadata     map[string]interface{}   `json:"metadata"`
+	Name         string                   `json:"name"`
+	PeriodEnd    int64                    `json:"period_end"`
+	PeriodStart  int64                    `json:"period_start"`
+	Plan         Expandable[Plan, string] `json:"plan"`
+	Prorated     bool                     `json:"prorated"`
+	Quantity     float64                  `json:"quantity"`
+	Taxable      bool                     `json:"taxable"`
+	Taxes        []Tax                    `json:"taxes"`
+	Type         string                   `json:"type"`
+	UnitCost     float64                  `json:"unit_cost"`
+}
+
+// Line items do not carry their currency, which is the one of their
+// document, e.g. item.UnitCostMoney(invoice.Currency).
+
+func (l *LineItem) UnitCostMoney(currency string) (Money, error) {
+	return CheckedMoneyFromFloat(l.UnitCost, currency)
+}
+
+func (l *LineItem) AmountMoney(currency string) (Money, error) {
+	return CheckedMoneyFromFloat(l.Amount, currency)
+}
+
+func (l *LineItem) PeriodStartTime() time.Time {
+	return Time(l.PeriodStart)
+}
+
+func (l *LineItem) PeriodEndTime() time.Time {
+	return Time(l.PeriodEnd)
 }
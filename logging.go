@@ -0,0 +1,266 @@
+package invoiced
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const redacted = "[REDACTED]"
+
+// maxLoggedBody caps how much of a response body is read to be logged, so
+// that logging does not hold large lists in memory. Longer bodies are not
+// logged, since a truncated body cannot be redacted.
+const maxLoggedBody = 64 << 10
+
+// DefaultRedactedFields are the JSON fields that are always masked when
+// request and response bodies are logged. They cover the card and bank
+// account details of payment sources and the tokens used to create them.
+var DefaultRedactedFields = []string{
+	"bank_name",
+	"exp_month",
+	"exp_year",
+	"gateway_customer",
+	"gateway_id",
+	"gateway_token",
+	"invoiced_token",
+	"last4",
+	"receipt_email",
+	"routing_number",
+}
+
+// WithLogger makes the Api log its activity to logger:
+//
+//   - every request at Info level, with method, endpoint, status, duration,
+//     request ID and, for list pages, the page number
+//   - every retry at Warn level, with the attempt number and wait time
+//   - request and response bodies at Debug level, with sensitive fields
+//     masked
+//
+// The API key is never logged. Use WithLogRedaction to mask more fields,
+// such as customer contact details.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithLogRedaction adds JSON fields to mask in logged bodies, in addition
+// to DefaultRedactedFields. Query parameters filtering on those fields, such
+// as filter[email], are masked in logged endpoints too.
+func WithLogRedaction(fields ...string) Option {
+	return func(o *options) {
+		o.redactedFields = append(o.redactedFields, fields...)
+	}
+}
+
+// SetLogger is the setter form of WithLogger. A nil logger disables logging.
+func (c *Api) SetLogger(logger *slog.Logger, redactedFields ...string) {
+	c.logger = logger
+	c.redactedFields = newRedactedFields(redactedFields)
+}
+
+func newRedactedFields(extra []string) map[string]bool {
+	fields := make(map[string]bool)
+
+	for _, field := range DefaultRedactedFields {
+		fields[field] = true
+	}
+
+	for _, field := range extra {
+		fields[strings.ToLower(field)] = true
+	}
+
+	return fields
+}
+
+func (c *Api) logRequests(next Handler) Handler {
+	return func(req *Request) (*http.Response, error) {
+		ctx := req.Context()
+		logger := c.logger
+		endpoint := c.redactEndpoint(req.Endpoint)
+
+		if logger.Enabled(ctx, slog.LevelDebug) && req.Payload != nil && isJSON(req.Header.Get("Content-Type")) {
+			logger.DebugContext(ctx, "invoiced request body",
+				slog.String("method", req.Method),
+				slog.String("endpoint", endpoint),
+				slog.String("body", c.redactBody(req.Payload)))
+		}
+
+		start := time.Now()
+		resp, err := next(req)
+		duration := time.Since(start)
+
+		if err != nil {
+			logger.ErrorContext(ctx, "invoiced request failed",
+				slog.String("method", req.Method),
+				slog.String("endpoint", endpoint),
+				slog.Duration("duration", duration),
+				slog.String("error", err.Error()))
+			return resp, err
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", req.Method),
+			slog.String("endpoint", endpoint),
+			slog.Int("status", resp.StatusCode),
+			slog.Duration("duration", duration),
+		}
+
+		if requestId := resp.Header.Get("X-Request-Id"); requestId != "" {
+			attrs = append(attrs, slog.String("request_id", requestId))
+		}
+
+		if page := pageNumber(req.Endpoint, resp.Header.Get("Link")); page != "" {
+			attrs = append(attrs, slog.String("page", page))
+		}
+
+		level := slog.LevelInfo
+		if resp.StatusCode >= 400 {
+			level = slog.LevelWarn
+		}
+
+		logger.LogAttrs(ctx, level, "invoiced request", attrs...)
+
+		if logger.Enabled(ctx, slog.LevelDebug) && isJSON(resp.Header.Get("Content-Type")) {
+			body, readErr := io.ReadAll(io.LimitReader(resp.Body, maxLoggedBody+1))
+
+			// the rest of the body is still streamed to the caller
+			resp.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+
+			if readErr == nil {
+				logged := fmt.Sprintf("[body of more than %d bytes]", maxLoggedBody)
+				if len(body) <= maxLoggedBody {
+					logged = c.redactBody(body)
+				}
+
+				logger.DebugContext(ctx, "invoiced response body",
+					slog.String("method", req.Method),
+					slog.String("endpoint", endpoint),
+					slog.String("body", logged))
+			}
+		}
+
+		return resp, nil
+	}
+}
+
+func (c *Api) logRetry(ctx context.Context, req *http.Request, attempt int, wait time.Duration, resp *http.Response, err error) {
+	if c.logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("endpoint", c.redactEndpoint(strings.TrimPrefix(req.URL.String(), c.baseUrl))),
+		slog.Int("attempt", attempt),
+		slog.Duration("wait", wait),
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	} else {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	}
+
+	c.logger.LogAttrs(ctx, slog.LevelWarn, "invoiced retry", attrs...)
+}
+
+// pageNumber finds the page of a list request, preferring the self link the
+// API returns over the page parameter of the endpoint.
+func pageNumber(endpoint string, link string) string {
+	if link != "" {
+		if self := parseLinkHeader(link)["self"]; self != "" {
+			if u, err := url.Parse(self); err == nil && u.Query().Get("page") != "" {
+				return u.Query().Get("page")
+			}
+		}
+	}
+
+	if u, err := url.Parse(endpoint); err == nil {
+		return u.Query().Get("page")
+	}
+
+	return ""
+}
+
+func (c *Api) redactEndpoint(endpoint string) string {
+	i := strings.Index(endpoint, "?")
+	if i < 0 {
+		return endpoint
+	}
+
+	values, err := url.ParseQuery(endpoint[i+1:])
+	if err != nil {
+		return endpoint[:i]
+	}
+
+	changed := false
+	for key := range values {
+		field := key
+		if open := strings.Index(key, "["); open >= 0 && strings.HasSuffix(key, "]") {
+			field = key[open+1 : len(key)-1]
+		}
+
+		if c.redactedFields[strings.ToLower(field)] {
+			values.Set(key, redacted)
+			changed = true
+		}
+	}
+
+	if !changed {
+		return endpoint
+	}
+
+	return endpoint[:i+1] + values.Encode()
+}
+
+func (c *Api) redactBody(body []byte) string {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return "[unparseable body]"
+	}
+
+	b, err := json.Marshal(redactValue(v, c.redactedFields))
+	if err != nil {
+		return "[unparseable body]"
+	}
+
+	return string(b)
+}
+
+func redactValue(v interface{}, fields map[string]bool) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, item := range value {
+			if fields[strings.ToLower(key)] && item != nil {
+				value[key] = redacted
+			} else {
+				value[key] = redactValue(item, fields)
+			}
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = redactValue(item, fields)
+		}
+	}
+
+	return v
+}
+
+func isJSON(contentType string) bool {
+	return strings.HasPrefix(contentType, requestType)
+}
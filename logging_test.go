@@ -0,0 +1,141 @@
+package invoiced
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/Invoiced/invoiced-go/v2/invdmockserver"
+)
+
+func newTestLogger(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+func TestLoggingRedactsSecrets(t *testing.T) {
+	card := map[string]interface{}{
+		"object":    "card",
+		"id":        42,
+		"brand":     "Visa",
+		"last4":     "4242",
+		"exp_month": 2,
+		"exp_year":  2030,
+	}
+
+	server, err := invdmockserver.NewScriptedServer(true,
+		invdmockserver.ScriptedResponse{Status: 200, Headers: map[string]string{"X-Request-Id": "req_123"}, Body: card},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	buf := new(bytes.Buffer)
+	key := "sk_secret_key"
+	client := NewMockApi(key, server.Server, WithLogger(newTestLogger(buf)), WithLogRedaction("email", "Phone"))
+
+	request := map[string]interface{}{
+		"gateway_token": "tok_visa",
+		"email":         "jane@example.com",
+		"phone":         "555-1234",
+		"name":          "Jane",
+	}
+
+	err = client.Create("/customers/1/payment_sources?filter[email]=jane@example.com", request, new(PaymentSource))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logs := buf.String()
+
+	for _, secret := range []string{key, base64.StdEncoding.EncodeToString([]byte(key + ":")), "tok_visa", "jane@example.com", "555-1234", "4242", "2030"} {
+		if strings.Contains(logs, secret) {
+			t.Fatal("Logs contain a secret:", secret, logs)
+		}
+	}
+
+	for _, expected := range []string{`"msg":"invoiced request"`, `"method":"POST"`, `"status":200`, `"request_id":"req_123"`, `"duration"`, `Jane`, `Visa`, `[REDACTED]`} {
+		if !strings.Contains(logs, expected) {
+			t.Fatal("Logs are missing", expected, logs)
+		}
+	}
+}
+
+func TestLoggingLargeResponse(t *testing.T) {
+	customers := make(Customers, 2000)
+	for i := range customers {
+		customers[i] = &Customer{Id: int64(i + 1), Name: strings.Repeat("x", 50)}
+	}
+
+	server, err := invdmockserver.NewScriptedServer(true, invdmockserver.ScriptedResponse{Status: 200, Body: customers})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	buf := new(bytes.Buffer)
+	client := NewMockApi("whatever", server.Server, WithLogger(newTestLogger(buf)))
+
+	list, err := CollectAll[Customers](context.Background(), client, "/customers")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(list) != 2000 || list[1999].Id != 2000 {
+		t.Fatal("The response was not fully read", len(list))
+	}
+
+	if !strings.Contains(buf.String(), "[body of more than 65536 bytes]") || strings.Contains(buf.String(), strings.Repeat("x", 50)) {
+		t.Fatal("Expected the large body not to be logged", buf.Len())
+	}
+}
+
+func TestLoggingPagesAndRetries(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(true,
+		invdmockserver.ScriptedResponse{Status: 503, Body: NewAPIError("api_error", "try again", "")},
+		invdmockserver.ScriptedResponse{
+			Status:  200,
+			Headers: map[string]string{"Link": `<https://api.invoiced.com/invoices?page=3>; rel="self", <https://api.invoiced.com/invoices?page=3>; rel="last"`},
+			Body:    Invoices{},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	buf := new(bytes.Buffer)
+	client := NewMockApi("whatever", server.Server, WithRetryPolicy(fastRetryPolicy()))
+	client.SetLogger(newTestLogger(buf))
+
+	_, err = client.Get("/invoices?page=3", new(Invoices))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logs := buf.String()
+
+	for _, expected := range []string{`"msg":"invoiced retry"`, `"attempt":1`, `"status":503`, `"page":"3"`} {
+		if !strings.Contains(logs, expected) {
+			t.Fatal("Logs are missing", expected, logs)
+		}
+	}
+}
+
+func TestPageNumber(t *testing.T) {
+	if page := pageNumber("/invoices?page=2", ""); page != "2" {
+		t.Fatal("Unexpected page", page)
+	}
+
+	link := `<https://api.invoiced.com/invoices?page=1>; rel="self", <https://api.invoiced.com/invoices?page=2>; rel="next"`
+	if page := pageNumber("/invoices", link); page != "1" {
+		t.Fatal("Unexpected page", page)
+	}
+
+	if page := pageNumber("/invoices/1", ""); page != "" {
+		t.Fatal("Unexpected page", page)
+	}
+}
@@ -1,6 +1,7 @@
 package member
 
 import (
+	"context"
 	"github.com/Invoiced/invoiced-go/v2"
 	"strconv"
 )
@@ -9,56 +10,110 @@ type Client struct {
 	*invoiced.Api
 }
 
+func (c *Client) resource() invoiced.Resource[invoiced.Member, invoiced.MemberRequest, int64] {
+	return invoiced.NewResource[invoiced.Member, invoiced.MemberRequest, int64](c.Api, "member", "/members")
+}
+
 func (c *Client) Create(request *invoiced.MemberRequest) (*invoiced.Member, error) {
-	resp := new(invoiced.Member)
-	err := c.Api.Create("/members", request, resp)
-	return resp, err
+	return c.CreateCtx(context.Background(), request)
+}
+
+func (c *Client) CreateCtx(ctx context.Context, request *invoiced.MemberRequest) (*invoiced.Member, error) {
+	return c.resource().CreateCtx(ctx, request)
 }
 
 func (c *Client) Retrieve(id int64) (*invoiced.Member, error) {
-	resp := new(invoiced.Member)
-	_, err := c.Api.Get("/members/"+strconv.FormatInt(id, 10), resp)
-	return resp, err
+	return c.RetrieveCtx(context.Background(), id)
+}
+
+func (c *Client) RetrieveCtx(ctx context.Context, id int64) (*invoiced.Member, error) {
+	return c.resource().RetrieveCtx(ctx, id)
 }
 
 func (c *Client) Update(id int64, request *invoiced.MemberRequest) (*invoiced.Member, error) {
-	resp := new(invoiced.Member)
-	err := c.Api.Update("/members/"+strconv.FormatInt(id, 10), request, resp)
-	return resp, err
+	return c.UpdateCtx(context.Background(), id, request)
+}
+
+func (c *Client) UpdateCtx(ctx context.Context, id int64, request *invoiced.MemberRequest) (*invoiced.Member, error) {
+	return c.resource().UpdateCtx(ctx, id, request)
 }
 
 func (c *Client) Delete(id int64) error {
-	return c.Api.Delete("/members/" + strconv.FormatInt(id, 10))
+	return c.DeleteCtx(context.Background(), id)
+}
+
+func (c *Client) DeleteCtx(ctx context.Context, id int64) error {
+	return c.resource().DeleteCtx(ctx, id)
 }
 
 func (c *Client) ListAll(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Members, error) {
-	endpoint := invoiced.AddFilterAndSort("/members", filter, sort)
+	return c.ListAllCtx(context.Background(), filter, sort)
+}
 
-	users := make(invoiced.Members, 0)
+func (c *Client) ListAllCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Members, error) {
+	return c.resource().ListAllCtx(ctx, filter, sort)
+}
 
-NEXT:
-	tmpUsers := make(invoiced.Members, 0)
+func (c *Client) Iterate(filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.Member] {
+	return c.IterateCtx(context.Background(), filter, sort)
+}
 
-	endpoint, err := c.Api.Get(endpoint, &tmpUsers)
+func (c *Client) IterateCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.Member] {
+	return c.resource().IterateCtx(ctx, filter, sort)
+}
 
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) List(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Members, string, error) {
+	return c.ListCtx(context.Background(), filter, sort)
+}
 
-	users = append(users, tmpUsers...)
+func (c *Client) ListCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Members, string, error) {
+	return c.resource().ListCtx(ctx, filter, sort)
+}
 
-	if endpoint != "" {
-		goto NEXT
-	}
+func (c *Client) ListQuery(query *invoiced.Query) (invoiced.Members, string, error) {
+	return c.ListQueryCtx(context.Background(), query)
+}
+
+func (c *Client) ListQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.Members, string, error) {
+	return c.resource().ListQueryCtx(ctx, query)
+}
+
+func (c *Client) ListAllQuery(query *invoiced.Query) (invoiced.Members, error) {
+	return c.ListAllQueryCtx(context.Background(), query)
+}
 
-	return users, nil
+func (c *Client) ListAllQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.Members, error) {
+	return c.resource().ListAllQueryCtx(ctx, query)
 }
 
-func (c *Client) SetUserEmailFrequency(id int64, request *invoiced.UserEmailUpdateRequest) (*Client, error) {
+func (c *Client) IterateQuery(query *invoiced.Query) *invoiced.Iter[*invoiced.Member] {
+	return c.IterateQueryCtx(context.Background(), query)
+}
+
+func (c *Client) IterateQueryCtx(ctx context.Context, query *invoiced.Query) *invoiced.Iter[*invoiced.Member] {
+	return c.resource().IterateQueryCtx(ctx, query)
+}
+
+func (c *Client) Count() (int64, error) {
+	return c.CountCtx(context.Background())
+}
+
+func (c *Client) CountCtx(ctx context.Context) (int64, error) {
+	return c.resource().CountCtx(ctx)
+}
+
+func (c *Client) SetUserEmailFrequency(id int64, request *invoiced.UserEmailUpdateRequest) (*invoiced.Member, error) {
+	return c.SetUserEmailFrequencyCtx(context.Background(), id, request)
+}
+
+func (c *Client) SetUserEmailFrequencyCtx(ctx context.Context, id int64, request *invoiced.UserEmailUpdateRequest) (_ *invoiced.Member, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "member.set_user_email_frequency")
+	defer func() { op.End(err) }()
+
 	endpoint := "/members/" + strconv.FormatInt(id, 10) + "/frequency"
 
-	resp := new(Client)
-	err := c.Api.Update(endpoint, request, resp)
+	resp := new(invoiced.Member)
+	err = c.Api.UpdateCtx(ctx, endpoint, request, resp)
 
 	if err != nil {
 		return nil, err
@@ -68,12 +123,19 @@ func (c *Client) SetUserEmailFrequency(id int64, request *invoiced.UserEmailUpda
 }
 
 func (c *Client) SendInvite(id int64) error {
+	return c.SendInviteCtx(context.Background(), id)
+}
+
+func (c *Client) SendInviteCtx(ctx context.Context, id int64) (err error) {
+	ctx, op := c.Api.StartOperation(ctx, "member.send_invite")
+	defer func() { op.End(err) }()
+
 	endpoint := "/members/" + strconv.FormatInt(id, 10) + "/invites"
 
 	request := new(invoiced.UserInvite)
 	request.Id = id
 
-	err := c.Api.Create(endpoint, request, nil)
+	err = c.Api.CreateCtx(ctx, endpoint, request, nil)
 
 	if err != nil {
 		return err
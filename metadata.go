@@ -0,0 +1,116 @@
+package invoiced
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResponseMetadata describes the HTTP response behind a client call.
+type ResponseMetadata struct {
+	StatusCode int
+	Header     http.Header
+	RequestId  string
+
+	// RateLimitRemaining is the number of requests left in the current
+	// rate limit window, or -1 when the API did not say.
+	RateLimitRemaining int
+
+	// TotalCount is the number of items of a list across all its pages, or
+	// -1 when the API did not say.
+	TotalCount int64
+
+	// Links are the pagination links of a list page, as endpoints relative
+	// to the API URL.
+	Links Links
+
+	// Duration is the time from sending the request until the response
+	// headers arrived, including retries.
+	Duration time.Duration
+}
+
+// Links are the pagination links sent in the Link header of a list page.
+// Missing links are empty.
+type Links struct {
+	Self  string
+	First string
+	Prev  string
+	Next  string
+	Last  string
+}
+
+type responseCapture struct {
+	mu   sync.Mutex
+	meta *ResponseMetadata
+}
+
+type responseCaptureContextKey struct{}
+
+// CaptureResponse returns a context that makes client calls issued with it
+// fill in meta once their response arrives:
+//
+//	var meta invoiced.ResponseMetadata
+//	customer, err := client.Customer.RetrieveCtx(invoiced.CaptureResponse(ctx, &meta), id)
+//	fmt.Println(meta.RequestId, meta.RateLimitRemaining)
+//
+// Calls that make several requests, such as ListAll, leave the metadata of
+// the last response received. Failed calls that got a response, such as a
+// 404, capture it too.
+func CaptureResponse(ctx context.Context, meta *ResponseMetadata) context.Context {
+	return context.WithValue(ctx, responseCaptureContextKey{}, &responseCapture{meta: meta})
+}
+
+func (c *Api) captureResponse(ctx context.Context, resp *http.Response, duration time.Duration) {
+	capture, ok := ctx.Value(responseCaptureContextKey{}).(*responseCapture)
+	if !ok || resp == nil {
+		return
+	}
+
+	meta := ResponseMetadata{
+		StatusCode:         resp.StatusCode,
+		Header:             resp.Header,
+		RequestId:          resp.Header.Get("X-Request-Id"),
+		RateLimitRemaining: -1,
+		TotalCount:         -1,
+		Links:              c.parseLinks(resp.Header.Get("Link")),
+		Duration:           duration,
+	}
+
+	if remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining")); err == nil {
+		meta.RateLimitRemaining = remaining
+	}
+
+	if total, err := strconv.ParseInt(resp.Header.Get("X-Total-Count"), 10, 64); err == nil {
+		meta.TotalCount = total
+	}
+
+	capture.mu.Lock()
+	*capture.meta = meta
+	capture.mu.Unlock()
+}
+
+func (c *Api) parseLinks(link string) Links {
+	if link == "" {
+		return Links{}
+	}
+
+	links := parseLinkHeader(link)
+	if _, ok := links["previous"]; !ok {
+		links["previous"] = links["prev"]
+	}
+
+	trim := func(rel string) string {
+		return strings.Replace(links[rel], c.baseUrl, "", -1)
+	}
+
+	return Links{
+		Self:  trim("self"),
+		First: trim("first"),
+		Prev:  trim("previous"),
+		Next:  trim("next"),
+		Last:  trim("last"),
+	}
+}
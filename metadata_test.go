@@ -0,0 +1,58 @@
+package invoiced
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Invoiced/invoiced-go/v2/invdmockserver"
+)
+
+func TestCaptureResponse(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(true,
+		invdmockserver.ScriptedResponse{
+			Status: 200,
+			Headers: map[string]string{
+				"X-Request-Id":          "req_1234",
+				"X-RateLimit-Remaining": "42",
+				"X-Total-Count":         "250",
+				"Link":                  `</customers?page=2>; rel="self", </customers?page=1>; rel="first", </customers?page=1>; rel="previous", </customers?page=3>; rel="next", </customers?page=3>; rel="last"`,
+			},
+			Body: Customers{{Id: 1}},
+		},
+		invdmockserver.ScriptedResponse{Status: 404, Body: NewAPIError("invalid_request", "not found", "")},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	client := NewMockApi("whatever", server.Server)
+
+	var meta ResponseMetadata
+	ctx := CaptureResponse(context.Background(), &meta)
+
+	if _, err := client.GetCtx(ctx, "/customers?page=2", new(Customers)); err != nil {
+		t.Fatal(err)
+	}
+
+	if meta.StatusCode != 200 || meta.RequestId != "req_1234" || meta.RateLimitRemaining != 42 || meta.TotalCount != 250 {
+		t.Fatal("Unexpected metadata", meta)
+	}
+
+	expected := Links{Self: "/customers?page=2", First: "/customers?page=1", Prev: "/customers?page=1", Next: "/customers?page=3", Last: "/customers?page=3"}
+	if meta.Links != expected {
+		t.Fatal("Unexpected links", meta.Links)
+	}
+
+	if meta.Duration <= 0 {
+		t.Fatal("Expected a duration")
+	}
+
+	if err := client.DeleteCtx(ctx, "/customers/1"); !IsNotFound(err) {
+		t.Fatal("Expected a not found error, got", err)
+	}
+
+	if meta.StatusCode != 404 || meta.RateLimitRemaining != -1 || meta.TotalCount != -1 || meta.Links != (Links{}) {
+		t.Fatal("Expected the error response to be captured", meta)
+	}
+}
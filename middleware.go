@@ -0,0 +1,63 @@
+package invoiced
+
+import (
+	"net/http"
+)
+
+// Request is an API request on its way through the middleware chain.
+type Request struct {
+	*http.Request
+
+	// Endpoint is the path and query of the request relative to the base
+	// URL, e.g. "/invoices?page=2".
+	Endpoint string
+
+	// Payload is the request body, or nil when the request has none or it
+	// is streamed, as for uploads. It is informational; a middleware that
+	// wants to send a different body must replace Request.Body (and
+	// Request.GetBody) instead.
+	Payload []byte
+}
+
+// Handler performs an API request and returns the raw response. The caller
+// takes care of checking the status and closing the body.
+type Handler func(req *Request) (*http.Response, error)
+
+// Middleware wraps a Handler to add behavior around every API request, such
+// as rewriting headers, audit logging or collecting metrics. A middleware may
+// also answer a request itself without calling next.
+type Middleware func(next Handler) Handler
+
+// Use appends middleware to the chain. The first middleware registered is the
+// outermost one. Automatic retries happen inside the chain, so a middleware
+// sees each API call once, with the final response.
+func (c *Api) Use(middleware ...Middleware) {
+	c.middleware = append(c.middleware, middleware...)
+}
+
+// WithMiddleware is the option form of Api.Use.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(o *options) {
+		o.middleware = append(o.middleware, middleware...)
+	}
+}
+
+func (c *Api) handler() Handler {
+	h := Handler(func(req *Request) (*http.Response, error) {
+		return c.do(req.Request)
+	})
+
+	if c.logger != nil {
+		h = c.logRequests(h)
+	}
+
+	if c.instrumentation != nil {
+		h = c.instrumentRequests(h)
+	}
+
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		h = c.middleware[i](h)
+	}
+
+	return h
+}
@@ -0,0 +1,136 @@
+package invoiced
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Invoiced/invoiced-go/v2/invdmockserver"
+)
+
+func TestMiddlewareChain(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(true,
+		invdmockserver.ScriptedResponse{Status: 200, Headers: map[string]string{"X-Total-Count": "3"}, Body: &Customer{Id: 1234}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	var calls []string
+	var seen []*Request
+	var statuses []int
+
+	outer := func(next Handler) Handler {
+		return func(req *Request) (*http.Response, error) {
+			calls = append(calls, "outer")
+			req.Header.Set("X-Correlation-Id", "abc")
+			return next(req)
+		}
+	}
+
+	inner := func(next Handler) Handler {
+		return func(req *Request) (*http.Response, error) {
+			calls = append(calls, "inner")
+			seen = append(seen, req)
+			resp, err := next(req)
+			if err == nil {
+				statuses = append(statuses, resp.StatusCode)
+			}
+			return resp, err
+		}
+	}
+
+	client := NewMockApi("whatever", server.Server, WithMiddleware(outer))
+	client.Use(inner)
+
+	err = client.Create("/customers", &CustomerRequest{Name: String("Jane")}, new(Customer))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Count("/customers")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "invoiced")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	filePath := filepath.Join(dir, "invoice.pdf")
+	if err = ioutil.WriteFile(filePath, []byte("%PDF-1.4"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.Upload("/files", filePath, "file", nil, "application/pdf", new(File))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Join(calls, ",") != "outer,inner,outer,inner,outer,inner" {
+		t.Fatal("Middleware ran in the wrong order", calls)
+	}
+
+	if seen[0].Method != "POST" || seen[0].Endpoint != "/customers" || string(seen[0].Payload) != `{"name":"Jane"}` {
+		t.Fatal("Unexpected request", seen[0].Method, seen[0].Endpoint, string(seen[0].Payload))
+	}
+
+	if seen[1].Method != "GET" || seen[1].Payload != nil {
+		t.Fatal("Unexpected request", seen[1].Method, string(seen[1].Payload))
+	}
+
+	if seen[2].Payload != nil || !strings.HasPrefix(seen[2].Header.Get("Content-Type"), "multipart/form-data") {
+		t.Fatal("Unexpected upload request", seen[2].Header.Get("Content-Type"), string(seen[2].Payload))
+	}
+
+	if len(statuses) != 3 || statuses[0] != 200 {
+		t.Fatal("Middleware did not see the responses", statuses)
+	}
+
+	for _, request := range server.Requests() {
+		if request.Header.Get("X-Correlation-Id") != "abc" {
+			t.Fatal("Header set by middleware was not sent")
+		}
+	}
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(true,
+		invdmockserver.ScriptedResponse{Status: 200, Body: &Customer{Id: 1234}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	client := NewMockApi("whatever", server.Server)
+	client.Use(func(next Handler) Handler {
+		return func(req *Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Header:     http.Header{},
+				Body:       ioutil.NopCloser(strings.NewReader(`{"id":5678}`)),
+			}, nil
+		}
+	})
+
+	customer := new(Customer)
+	_, err = client.Get("/customers/1234", customer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if customer.Id != 5678 {
+		t.Fatal("Response from middleware was not used", customer.Id)
+	}
+
+	if len(server.Requests()) != 0 {
+		t.Fatal("Request should not have reached the server")
+	}
+}
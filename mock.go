@@ -2,14 +2,14 @@ package invoiced
 
 import (
 	"crypto/tls"
-	"net/http"
 	"net/http/httptest"
 )
 
 // NewMockApi is helpful when writing tests for
 // functions that use an InvoiceClient connection to interact
 // with the InvoiceClient API. It requires an arbitrary string
-// as its Key parameter and an initialized http server.
+// as its Key parameter and an initialized http server. Any options
+// are applied after the ones pointing the Api at the server.
 //
 // Example (error checking omitted):
 //	Key := "test api Key"
@@ -28,16 +28,11 @@ import (
 // 			name = "github.com/Invoiced/invoiced-go"
 // 			unused-packages = false
 
-func NewMockApi(key string, server *httptest.Server) *Api {
-	c := new(Api)
-	c.Key = key
+func NewMockApi(key string, server *httptest.Server, opts ...Option) *Api {
+	opts = append([]Option{
+		WithBaseUrl(server.URL),
+		WithTLSConfig(&tls.Config{InsecureSkipVerify: true}),
+	}, opts...)
 
-	transport := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-	}
-
-	c.client = &http.Client{Transport: transport}
-	c.baseUrl = server.URL
-
-	return c
+	return New(key, false, opts...)
 }
@@ -0,0 +1,454 @@
+package invoiced
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// RoundingMode decides which way an amount exactly halfway between two
+// rounded values goes.
+type RoundingMode int
+
+const (
+	// RoundHalfEven rounds halves to the even neighbour, e.g. 0.125 to 0.12
+	// and 0.135 to 0.14, so that rounding errors cancel out over many
+	// amounts. It is also known as banker's rounding.
+	RoundHalfEven RoundingMode = iota
+	// RoundHalfUp rounds halves away from zero, e.g. 0.125 to 0.13 and
+	// -0.125 to -0.13.
+	RoundHalfUp
+)
+
+// ErrCurrencyMismatch is returned when combining amounts of two different
+// currencies.
+var ErrCurrencyMismatch = errors.New("invoiced: currency mismatch")
+
+// Money is an exact decimal amount in a currency. Unlike float64, sums and
+// products of Money never drift, e.g. adding 0.1 ten times is exactly 1.
+//
+// The zero value is an amount of 0 without a currency. An amount without a
+// currency takes the currency of the amounts it is combined with; combining
+// amounts of two different currencies with Add, Sub or Cmp panics, while
+// CheckedAdd, CheckedSub and CheckedCmp return ErrCurrencyMismatch.
+//
+// The amounts of the models, such as Invoice.Total, remain float64 for
+// compatibility. Their Money accessors, e.g. Invoice.TotalMoney, recover the
+// exact decimal sent by the API for amounts of up to 15 significant digits,
+// and return an error for NaN and infinities.
+type Money struct {
+	coef     *big.Int // the amount is coef / 10^scale
+	scale    int
+	currency string
+}
+
+// minorUnits lists the ISO 4217 currencies that do not have 2 decimal
+// places.
+var minorUnits = map[string]int{
+	"bif": 0, "clp": 0, "djf": 0, "gnf": 0, "isk": 0, "jpy": 0, "kmf": 0, "krw": 0,
+	"pyg": 0, "rwf": 0, "ugx": 0, "uyi": 0, "vnd": 0, "vuv": 0, "xaf": 0, "xof": 0, "xpf": 0,
+	"bhd": 3, "iqd": 3, "jod": 3, "kwd": 3, "lyd": 3, "omr": 3, "tnd": 3,
+	"clf": 4, "uyw": 4,
+}
+
+// CurrencyMinorUnits returns the number of decimal places of an ISO 4217
+// currency, e.g. 2 for "usd" and 0 for "jpy". The code is case-insensitive.
+func CurrencyMinorUnits(currency string) int {
+	if units, ok := minorUnits[strings.ToLower(currency)]; ok {
+		return units
+	}
+
+	return 2
+}
+
+// NewMoney returns an amount given in the minor units of its currency, e.g.
+// NewMoney(1250, "usd") is 12.50 USD.
+func NewMoney(minor int64, currency string) Money {
+	return Money{coef: big.NewInt(minor), scale: CurrencyMinorUnits(currency), currency: currency}
+}
+
+// ParseMoney parses a decimal amount such as "-1234.5678".
+func ParseMoney(amount, currency string) (Money, error) {
+	coef, scale, ok := parseDecimal(amount)
+	if !ok {
+		return Money{}, fmt.Errorf("invoiced: invalid amount %q", amount)
+	}
+
+	return Money{coef: coef, scale: scale, currency: currency}, nil
+}
+
+// MoneyFromFloat converts an amount decoded as a float64. It uses the
+// shortest decimal that decodes to the same float64, which is the decimal
+// sent by the API for amounts of up to 15 significant digits. It panics on
+// NaN and infinities; CheckedMoneyFromFloat returns an error instead.
+func MoneyFromFloat(amount float64, currency string) Money {
+	m, err := CheckedMoneyFromFloat(amount, currency)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	return m
+}
+
+// CheckedMoneyFromFloat is MoneyFromFloat returning an error for NaN and
+// infinities instead of panicking.
+func CheckedMoneyFromFloat(amount float64, currency string) (Money, error) {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return Money{}, fmt.Errorf("invoiced: invalid amount %v", amount)
+	}
+
+	return ParseMoney(strconv.FormatFloat(amount, 'f', -1, 64), currency)
+}
+
+// sumMoney adds up amounts decoded as float64s, exactly.
+func sumMoney(currency string, amounts []float64) (Money, error) {
+	total := NewMoney(0, currency)
+
+	for _, amount := range amounts {
+		m, err := CheckedMoneyFromFloat(amount, currency)
+		if err != nil {
+			return Money{}, err
+		}
+
+		total = total.Add(m)
+	}
+
+	return total, nil
+}
+
+// parseDecimal parses a decimal number, including the exponent notation
+// allowed by JSON, into a coefficient and a number of decimal places.
+func parseDecimal(s string) (*big.Int, int, bool) {
+	mantissa, exponent := s, 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.Atoi(s[i+1:])
+		if err != nil || e > 1000 || e < -1000 {
+			return nil, 0, false
+		}
+
+		mantissa, exponent = s[:i], e
+	}
+
+	whole, frac, _ := strings.Cut(mantissa, ".")
+
+	sign := ""
+	if whole != "" && (whole[0] == '-' || whole[0] == '+') {
+		sign, whole = whole[:1], whole[1:]
+	}
+
+	if whole+frac == "" || !isDigits(whole) || !isDigits(frac) {
+		return nil, 0, false
+	}
+
+	coef, ok := new(big.Int).SetString(sign+whole+frac, 10)
+	if !ok {
+		return nil, 0, false
+	}
+
+	scale := len(frac) - exponent
+	if scale < 0 {
+		coef.Mul(coef, pow10(-scale))
+		scale = 0
+	}
+
+	return coef, scale, true
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+func (m Money) coefficient() *big.Int {
+	if m.coef == nil {
+		return new(big.Int)
+	}
+
+	return m.coef
+}
+
+// rescaled returns the coefficient of the amount with the given number of
+// decimal places, which must not be less than the scale of the amount.
+func (m Money) rescaled(scale int) *big.Int {
+	return new(big.Int).Mul(m.coefficient(), pow10(scale-m.scale))
+}
+
+func (m Money) Currency() string {
+	return m.currency
+}
+
+// WithCurrency returns the same amount in the given currency.
+func (m Money) WithCurrency(currency string) Money {
+	m.currency = currency
+	return m
+}
+
+func (m Money) combinedCurrency(o Money) (string, error) {
+	switch {
+	case m.currency == "":
+		return o.currency, nil
+	case o.currency == "" || strings.EqualFold(m.currency, o.currency):
+		return m.currency, nil
+	}
+
+	return "", fmt.Errorf("%w: cannot combine %s and %s amounts", ErrCurrencyMismatch, m.currency, o.currency)
+}
+
+// Add panics if the amounts are of different currencies.
+func (m Money) Add(o Money) Money {
+	sum, err := m.CheckedAdd(o)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	return sum
+}
+
+// CheckedAdd is Add returning ErrCurrencyMismatch instead of panicking.
+func (m Money) CheckedAdd(o Money) (Money, error) {
+	currency, err := m.combinedCurrency(o)
+	if err != nil {
+		return Money{}, err
+	}
+
+	scale := max(m.scale, o.scale)
+
+	return Money{coef: new(big.Int).Add(m.rescaled(scale), o.rescaled(scale)), scale: scale, currency: currency}, nil
+}
+
+func (m Money) Sub(o Money) Money {
+	return m.Add(o.Neg())
+}
+
+// CheckedSub is Sub returning ErrCurrencyMismatch instead of panicking.
+func (m Money) CheckedSub(o Money) (Money, error) {
+	return m.CheckedAdd(o.Neg())
+}
+
+func (m Money) Neg() Money {
+	return Money{coef: new(big.Int).Neg(m.coefficient()), scale: m.scale, currency: m.currency}
+}
+
+// Mul multiplies the amount by a factor such as a quantity or a rate, e.g.
+// the unit cost of a line item by its quantity. The result is exact; round
+// it to get an amount that can be charged. It panics if the factor is NaN or
+// infinite.
+func (m Money) Mul(factor float64) Money {
+	product, err := m.CheckedMul(factor)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	return product
+}
+
+// CheckedMul is Mul returning an error for a NaN or infinite factor instead
+// of panicking.
+func (m Money) CheckedMul(factor float64) (Money, error) {
+	f, err := CheckedMoneyFromFloat(factor, "")
+	if err != nil {
+		return Money{}, err
+	}
+
+	return Money{coef: new(big.Int).Mul(m.coefficient(), f.coefficient()), scale: m.scale + f.scale, currency: m.currency}, nil
+}
+
+// Cmp compares the amounts, returning -1, 0 or +1. It panics if the amounts
+// are of different currencies, which cannot be compared.
+func (m Money) Cmp(o Money) int {
+	c, err := m.CheckedCmp(o)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	return c
+}
+
+// CheckedCmp is Cmp returning ErrCurrencyMismatch instead of panicking.
+func (m Money) CheckedCmp(o Money) (int, error) {
+	if _, err := m.combinedCurrency(o); err != nil {
+		return 0, err
+	}
+
+	scale := max(m.scale, o.scale)
+
+	return m.rescaled(scale).Cmp(o.rescaled(scale)), nil
+}
+
+func (m Money) Sign() int {
+	return m.coefficient().Sign()
+}
+
+func (m Money) IsZero() bool {
+	return m.Sign() == 0
+}
+
+// Round rounds the amount to the given number of decimal places.
+func (m Money) Round(places int, mode RoundingMode) Money {
+	if places < 0 {
+		places = 0
+	}
+
+	if m.scale <= places {
+		return Money{coef: m.rescaled(places), scale: places, currency: m.currency}
+	}
+
+	divisor := pow10(m.scale - places)
+	quotient, remainder := new(big.Int).QuoRem(m.coefficient(), divisor, new(big.Int))
+
+	// compare twice the remainder with the divisor to find halves
+	half := new(big.Int).Abs(remainder)
+	half.Lsh(half, 1)
+
+	c := half.Cmp(divisor)
+	if c > 0 || (c == 0 && (mode == RoundHalfUp || quotient.Bit(0) == 1)) {
+		quotient.Add(quotient, big.NewInt(int64(m.coefficient().Sign())))
+	}
+
+	return Money{coef: quotient, scale: places, currency: m.currency}
+}
+
+// RoundToCurrency rounds the amount to the minor units of its currency, e.g.
+// cents for USD.
+func (m Money) RoundToCurrency(mode RoundingMode) Money {
+	return m.Round(CurrencyMinorUnits(m.currency), mode)
+}
+
+// Allocate splits the amount, rounded half-even to the minor units of its
+// currency, into parts proportional to the weights. The parts always add up
+// to the rounded amount: the minor units left over by the division go one
+// each to the first parts. For example, 100.00 split in three is 33.34,
+// 33.33 and 33.33.
+func (m Money) Allocate(weights ...int64) []Money {
+	total := m.RoundToCurrency(RoundHalfEven)
+	coef := total.coefficient()
+
+	sum := new(big.Int)
+	for _, w := range weights {
+		if w < 0 {
+			panic("invoiced: negative allocation weight")
+		}
+
+		sum.Add(sum, big.NewInt(w))
+	}
+
+	parts := make([]Money, len(weights))
+	if sum.Sign() == 0 {
+		for i := range parts {
+			parts[i] = Money{coef: new(big.Int), scale: total.scale, currency: m.currency}
+		}
+
+		return parts
+	}
+
+	allocated := new(big.Int)
+	for i, w := range weights {
+		share := new(big.Int).Mul(coef, big.NewInt(w))
+		share.Quo(share, sum)
+		allocated.Add(allocated, share)
+		parts[i] = Money{coef: share, scale: total.scale, currency: m.currency}
+	}
+
+	// hand out what the truncated divisions left, one minor unit at a time
+	left := new(big.Int).Sub(coef, allocated)
+	unit := big.NewInt(int64(left.Sign()))
+	for i := 0; left.Sign() != 0; i = (i + 1) % len(parts) {
+		if weights[i] == 0 {
+			continue
+		}
+
+		parts[i].coef.Add(parts[i].coef, unit)
+		left.Sub(left, unit)
+	}
+
+	return parts
+}
+
+// MinorUnits returns the amount in the minor units of its currency, e.g.
+// 1250 for 12.50 USD. It returns false if the amount has more decimal places
+// than the currency or does not fit an int64.
+func (m Money) MinorUnits() (int64, bool) {
+	minor := CurrencyMinorUnits(m.currency)
+	if m.Round(minor, RoundHalfEven).Cmp(m) != 0 {
+		return 0, false
+	}
+
+	coef := m.Round(minor, RoundHalfEven).coefficient()
+	if !coef.IsInt64() {
+		return 0, false
+	}
+
+	return coef.Int64(), true
+}
+
+// Float64 returns the nearest float64, e.g. to set the amount of a request
+// with invoiced.Float64(m.Float64()). Such amounts are sent to the API as
+// the exact decimal as long as they have at most 15 significant digits.
+func (m Money) Float64() float64 {
+	f, _ := strconv.ParseFloat(m.String(), 64)
+	return f
+}
+
+// String formats the amount as a decimal with all its decimal places,
+// without the currency, e.g. "-12.50".
+func (m Money) String() string {
+	digits := new(big.Int).Abs(m.coefficient()).String()
+
+	sign := ""
+	if m.Sign() < 0 {
+		sign = "-"
+	}
+
+	if m.scale == 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= m.scale {
+		digits = strings.Repeat("0", m.scale-len(digits)+1) + digits
+	}
+
+	return sign + digits[:len(digits)-m.scale] + "." + digits[len(digits)-m.scale:]
+}
+
+// MarshalJSON encodes the amount as a JSON number, exactly.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON decodes a JSON number, or a string holding one, exactly. The
+// currency of m is kept, since the amounts of the API do not carry one.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+
+	parsed, err := ParseMoney(s, m.currency)
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+
+	return nil
+}
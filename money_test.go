@@ -0,0 +1,213 @@
+package invoiced
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func mustParseMoney(t *testing.T, amount, currency string) Money {
+	t.Helper()
+
+	m, err := ParseMoney(amount, currency)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return m
+}
+
+func TestParseMoney(t *testing.T) {
+	cases := map[string]string{
+		"12.50":   "12.50",
+		"-0.05":   "-0.05",
+		"+3":      "3",
+		".5":      "0.5",
+		"1.25e2":  "125",
+		"125E-4":  "0.0125",
+		"0.00100": "0.00100",
+	}
+
+	for input, expected := range cases {
+		if m := mustParseMoney(t, input, "usd"); m.String() != expected {
+			t.Fatal("Unexpected amount for", input, m.String())
+		}
+	}
+
+	for _, input := range []string{"", "-", "1.2.3", "12a", "1e", "--1", "1,000"} {
+		if _, err := ParseMoney(input, "usd"); err == nil {
+			t.Fatal("Expected an error for", input)
+		}
+	}
+
+	if NewMoney(1250, "usd").String() != "12.50" || NewMoney(1250, "jpy").String() != "1250" || NewMoney(-5, "kwd").String() != "-0.005" {
+		t.Fatal("Unexpected NewMoney")
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	sum := Money{}
+	for i := 0; i < 10; i++ {
+		sum = sum.Add(MoneyFromFloat(0.1, "usd"))
+	}
+
+	if sum.Cmp(NewMoney(100, "usd")) != 0 || sum.Currency() != "usd" {
+		t.Fatal("Expected 0.1 added ten times to be exactly 1, got", sum)
+	}
+
+	lineTotal := MoneyFromFloat(1999.22, "usd").Mul(3)
+	if lineTotal.String() != "5997.66" {
+		t.Fatal("Unexpected product", lineTotal)
+	}
+
+	if d := NewMoney(1000, "usd").Sub(MoneyFromFloat(0.01, "usd")); d.String() != "9.99" || d.Sign() != 1 {
+		t.Fatal("Unexpected difference", d)
+	}
+
+	if _, err := CheckedMoneyFromFloat(math.NaN(), "usd"); err == nil {
+		t.Fatal("Expected NaN to fail")
+	}
+
+	if _, err := NewMoney(1, "usd").CheckedMul(math.Inf(-1)); err == nil {
+		t.Fatal("Expected an infinite factor to fail")
+	}
+
+	if _, err := NewMoney(1, "usd").CheckedAdd(NewMoney(1, "eur")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Fatal("Expected a currency mismatch, got", err)
+	}
+
+	if _, err := NewMoney(1, "usd").CheckedCmp(NewMoney(1, "eur")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Fatal("Expected a currency mismatch, got", err)
+	}
+
+	if c, err := NewMoney(1, "usd").CheckedCmp(NewMoney(2, "USD")); err != nil || c != -1 {
+		t.Fatal("Unexpected comparison", c, err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Expected adding different currencies to panic")
+		}
+	}()
+
+	NewMoney(1, "usd").Add(NewMoney(1, "eur"))
+}
+
+func TestMoneyRound(t *testing.T) {
+	cases := []struct {
+		amount   string
+		mode     RoundingMode
+		expected string
+	}{
+		{"0.125", RoundHalfEven, "0.12"},
+		{"0.135", RoundHalfEven, "0.14"},
+		{"0.125", RoundHalfUp, "0.13"},
+		{"-0.125", RoundHalfUp, "-0.13"},
+		{"-0.125", RoundHalfEven, "-0.12"},
+		{"0.1251", RoundHalfEven, "0.13"},
+		{"2.5", RoundHalfEven, "2.50"},
+	}
+
+	for _, c := range cases {
+		if rounded := mustParseMoney(t, c.amount, "usd").Round(2, c.mode); rounded.String() != c.expected {
+			t.Fatal("Unexpected rounding of", c.amount, rounded)
+		}
+	}
+
+	if m := mustParseMoney(t, "1234.5", "jpy").RoundToCurrency(RoundHalfEven); m.String() != "1234" {
+		t.Fatal("Unexpected yen rounding", m)
+	}
+
+	if m := mustParseMoney(t, "1.2345", "KWD").RoundToCurrency(RoundHalfUp); m.String() != "1.235" {
+		t.Fatal("Unexpected dinar rounding", m)
+	}
+}
+
+func TestMoneyAllocate(t *testing.T) {
+	parts := NewMoney(10000, "usd").Allocate(1, 1, 1)
+	if parts[0].String() != "33.34" || parts[1].String() != "33.33" || parts[2].String() != "33.33" {
+		t.Fatal("Unexpected parts", parts)
+	}
+
+	parts = mustParseMoney(t, "-0.05", "usd").Allocate(1, 0, 1)
+	if parts[0].String() != "-0.03" || parts[1].String() != "0.00" || parts[2].String() != "-0.02" {
+		t.Fatal("Unexpected parts", parts)
+	}
+}
+
+func TestMoneyMinorUnits(t *testing.T) {
+	if units, ok := MoneyFromFloat(12.5, "usd").MinorUnits(); !ok || units != 1250 {
+		t.Fatal("Unexpected minor units", units, ok)
+	}
+
+	if _, ok := MoneyFromFloat(12.505, "usd").MinorUnits(); ok {
+		t.Fatal("Expected fractions of cents to be reported")
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	var payload struct {
+		Amount Money `json:"amount"`
+		Quoted Money `json:"quoted"`
+	}
+
+	payload.Amount = payload.Amount.WithCurrency("usd")
+
+	if err := json.Unmarshal([]byte(`{"amount":1234567890123.456789,"quoted":"0.10"}`), &payload); err != nil {
+		t.Fatal(err)
+	}
+
+	if payload.Amount.String() != "1234567890123.456789" || payload.Amount.Currency() != "usd" || payload.Quoted.String() != "0.10" {
+		t.Fatal("Unexpected amounts", payload.Amount, payload.Quoted)
+	}
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != `{"amount":1234567890123.456789,"quoted":0.10}` {
+		t.Fatal("Unexpected JSON", string(b))
+	}
+
+	if err := json.Unmarshal([]byte(`{"amount":"ten"}`), &payload); err == nil {
+		t.Fatal("Expected an error")
+	}
+}
+
+func TestMoneyModelAdapters(t *testing.T) {
+	invoice := &Invoice{
+		Currency: "usd",
+		Total:    0.3,
+		Items: []LineItem{
+			{Taxes: []Tax{{Amount: 0.1}}},
+			{Taxes: []Tax{{Amount: 0.2}}},
+		},
+	}
+
+	tax, err := invoice.TotalTaxMoney()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if total, _ := invoice.TotalMoney(); tax.Cmp(total) != 0 {
+		t.Fatal("Expected the taxes to add up exactly, got", tax)
+	}
+
+	invoice.Discounts = []Discount{{Amount: math.NaN()}}
+	if _, err := invoice.TotalDiscountMoney(); err == nil {
+		t.Fatal("Expected a NaN discount to fail")
+	}
+
+	invoice.Balance = math.Inf(1)
+	if _, err := invoice.BalanceMoney(); err == nil {
+		t.Fatal("Expected an infinite balance to fail")
+	}
+
+	request := &PaymentRequest{}
+	request.SetAmount(NewMoney(2599, "eur"))
+	if *request.Amount != 25.99 || *request.Currency != "eur" {
+		t.Fatal("Unexpected request", *request.Amount, *request.Currency)
+	}
+}
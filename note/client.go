@@ -1,49 +1,94 @@
 package note
 
 import (
+	"context"
 	"github.com/Invoiced/invoiced-go/v2"
-	"strconv"
 )
 
 type Client struct {
 	*invoiced.Api
 }
 
+func (c *Client) resource() invoiced.Resource[invoiced.Note, invoiced.NoteRequest, int64] {
+	return invoiced.NewResource[invoiced.Note, invoiced.NoteRequest, int64](c.Api, "note", "/notes")
+}
+
 func (c *Client) Create(request *invoiced.NoteRequest) (*invoiced.Note, error) {
-	resp := new(invoiced.Note)
-	err := c.Api.Create("/roles", request, resp)
-	return resp, err
+	return c.CreateCtx(context.Background(), request)
+}
+
+func (c *Client) CreateCtx(ctx context.Context, request *invoiced.NoteRequest) (*invoiced.Note, error) {
+	return c.resource().CreateCtx(ctx, request)
 }
 
 func (c *Client) Update(id int64, request *invoiced.NoteRequest) (*invoiced.Note, error) {
-	resp := new(invoiced.Note)
-	err := c.Api.Update("/notes/"+strconv.FormatInt(id, 10), request, resp)
-	return resp, err
+	return c.UpdateCtx(context.Background(), id, request)
+}
+
+func (c *Client) UpdateCtx(ctx context.Context, id int64, request *invoiced.NoteRequest) (*invoiced.Note, error) {
+	return c.resource().UpdateCtx(ctx, id, request)
 }
 
 func (c *Client) Delete(id int64) error {
-	return c.Api.Delete("/notes/" + strconv.FormatInt(id, 10))
+	return c.DeleteCtx(context.Background(), id)
+}
+
+func (c *Client) DeleteCtx(ctx context.Context, id int64) error {
+	return c.resource().DeleteCtx(ctx, id)
 }
 
 func (c *Client) ListAll(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Notes, error) {
-	endpoint := invoiced.AddFilterAndSort("/notes", filter, sort)
+	return c.ListAllCtx(context.Background(), filter, sort)
+}
 
-	notes := make(invoiced.Notes, 0)
+func (c *Client) ListAllCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Notes, error) {
+	return c.resource().ListAllCtx(ctx, filter, sort)
+}
 
-NEXT:
-	tmpNotes := make(invoiced.Notes, 0)
+func (c *Client) Iterate(filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.Note] {
+	return c.IterateCtx(context.Background(), filter, sort)
+}
 
-	endpointTmp, err := c.Api.Get(endpoint, &tmpNotes)
+func (c *Client) IterateCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.Note] {
+	return c.resource().IterateCtx(ctx, filter, sort)
+}
 
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) List(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Notes, string, error) {
+	return c.ListCtx(context.Background(), filter, sort)
+}
 
-	notes = append(notes, tmpNotes...)
+func (c *Client) ListCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Notes, string, error) {
+	return c.resource().ListCtx(ctx, filter, sort)
+}
 
-	if endpointTmp != "" {
-		goto NEXT
-	}
+func (c *Client) ListQuery(query *invoiced.Query) (invoiced.Notes, string, error) {
+	return c.ListQueryCtx(context.Background(), query)
+}
+
+func (c *Client) ListQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.Notes, string, error) {
+	return c.resource().ListQueryCtx(ctx, query)
+}
+
+func (c *Client) ListAllQuery(query *invoiced.Query) (invoiced.Notes, error) {
+	return c.ListAllQueryCtx(context.Background(), query)
+}
+
+func (c *Client) ListAllQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.Notes, error) {
+	return c.resource().ListAllQueryCtx(ctx, query)
+}
+
+func (c *Client) IterateQuery(query *invoiced.Query) *invoiced.Iter[*invoiced.Note] {
+	return c.IterateQueryCtx(context.Background(), query)
+}
+
+func (c *Client) IterateQueryCtx(ctx context.Context, query *invoiced.Query) *invoiced.Iter[*invoiced.Note] {
+	return c.resource().IterateQueryCtx(ctx, query)
+}
+
+func (c *Client) Count() (int64, error) {
+	return c.CountCtx(context.Background())
+}
 
-	return notes, nil
+func (c *Client) CountCtx(ctx context.Context) (int64, error) {
+	return c.resource().CountCtx(ctx)
 }
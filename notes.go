@@ -1,5 +1,7 @@
 package invoiced
 
+import "time"
+
 type NoteRequest struct {
 	Customer *int64  `json:"customer_id,omitempty"`
 	Invoice  *int64  `json:"invoice_id,omitempty"`
@@ -7,13 +9,21 @@ type NoteRequest struct {
 }
 
 type Note struct {
-	CreatedAt int64  `json:"created_at"`
-	Customer  int64  `json:"customer"`
-	Id        int64  `json:"id"`
-	Notes     string `json:"notes"`
-	Object    string `json:"object"`
-	UpdatedAt int64  `json:"updated_at"`
-	User      *User  `json:"user"`
+	CreatedAt int64                       `json:"created_at"`
+	Customer  Expandable[Customer, int64] `json:"customer"`
+	Id        int64                       `json:"id"`
+	Notes     string                      `json:"notes"`
+	Object    string                      `json:"object"`
+	UpdatedAt int64                       `json:"updated_at"`
+	User      Expandable[User, int64]     `json:"user"`
 }
 
 type Notes []*Note
+
+func (n *Note) CreatedAtTime() time.Time {
+	return Time(n.CreatedAt)
+}
+
+func (n *Note) UpdatedAtTime() time.Time {
+	return Time(n.UpdatedAt)
+}
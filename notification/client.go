@@ -1,55 +1,102 @@
 package notification
 
 import (
+	"context"
 	"github.com/Invoiced/invoiced-go/v2"
-	"strconv"
 )
 
 type Client struct {
 	*invoiced.Api
 }
 
+func (c *Client) resource() invoiced.Resource[invoiced.Notification, invoiced.NotificationRequest, int64] {
+	return invoiced.NewResource[invoiced.Notification, invoiced.NotificationRequest, int64](c.Api, "notification", "/notifications")
+}
+
 func (c *Client) Create(request *invoiced.NotificationRequest) (*invoiced.Notification, error) {
-	resp := new(invoiced.Notification)
-	err := c.Api.Create("/notifications", request, resp)
-	return resp, err
+	return c.CreateCtx(context.Background(), request)
+}
+
+func (c *Client) CreateCtx(ctx context.Context, request *invoiced.NotificationRequest) (*invoiced.Notification, error) {
+	return c.resource().CreateCtx(ctx, request)
 }
 
-func (c *Client) Update(request *invoiced.NotificationRequest, id int64) (*invoiced.Notification, error) {
-	resp := new(invoiced.Notification)
-	err := c.Api.Update("/notifications/"+strconv.FormatInt(id, 10), request, resp)
-	return resp, err
+func (c *Client) Update(id int64, request *invoiced.NotificationRequest) (*invoiced.Notification, error) {
+	return c.UpdateCtx(context.Background(), id, request)
+}
+
+func (c *Client) UpdateCtx(ctx context.Context, id int64, request *invoiced.NotificationRequest) (*invoiced.Notification, error) {
+	return c.resource().UpdateCtx(ctx, id, request)
 }
 
 func (c *Client) Delete(id int64) error {
-	return c.Api.Delete("/notifications/" + strconv.FormatInt(id, 10))
+	return c.DeleteCtx(context.Background(), id)
+}
+
+func (c *Client) DeleteCtx(ctx context.Context, id int64) error {
+	return c.resource().DeleteCtx(ctx, id)
 }
 
 func (c *Client) Retrieve(id int64) (*invoiced.Notification, error) {
-	resp := new(invoiced.Notification)
-	_, err := c.Api.Get("/notifications/"+strconv.FormatInt(id, 10), resp)
-	return resp, err
+	return c.RetrieveCtx(context.Background(), id)
+}
+
+func (c *Client) RetrieveCtx(ctx context.Context, id int64) (*invoiced.Notification, error) {
+	return c.resource().RetrieveCtx(ctx, id)
 }
 
 func (c *Client) ListAll(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Notifications, error) {
-	endpoint := invoiced.AddFilterAndSort("/notifications", filter, sort)
+	return c.ListAllCtx(context.Background(), filter, sort)
+}
 
-	notifications := make(invoiced.Notifications, 0)
+func (c *Client) ListAllCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Notifications, error) {
+	return c.resource().ListAllCtx(ctx, filter, sort)
+}
 
-NEXT:
-	tmpNotifications := make(invoiced.Notifications, 0)
+func (c *Client) Iterate(filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.Notification] {
+	return c.IterateCtx(context.Background(), filter, sort)
+}
 
-	endpoint, err := c.Api.Get(endpoint, &tmpNotifications)
+func (c *Client) IterateCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.Notification] {
+	return c.resource().IterateCtx(ctx, filter, sort)
+}
 
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) List(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Notifications, string, error) {
+	return c.ListCtx(context.Background(), filter, sort)
+}
 
-	notifications = append(notifications, tmpNotifications...)
+func (c *Client) ListCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Notifications, string, error) {
+	return c.resource().ListCtx(ctx, filter, sort)
+}
+
+func (c *Client) ListQuery(query *invoiced.Query) (invoiced.Notifications, string, error) {
+	return c.ListQueryCtx(context.Background(), query)
+}
+
+func (c *Client) ListQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.Notifications, string, error) {
+	return c.resource().ListQueryCtx(ctx, query)
+}
+
+func (c *Client) ListAllQuery(query *invoiced.Query) (invoiced.Notifications, error) {
+	return c.ListAllQueryCtx(context.Background(), query)
+}
 
-	if endpoint != "" {
-		goto NEXT
-	}
+func (c *Client) ListAllQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.Notifications, error) {
+	return c.resource().ListAllQueryCtx(ctx, query)
+}
+
+func (c *Client) IterateQuery(query *invoiced.Query) *invoiced.Iter[*invoiced.Notification] {
+	return c.IterateQueryCtx(context.Background(), query)
+}
+
+func (c *Client) IterateQueryCtx(ctx context.Context, query *invoiced.Query) *invoiced.Iter[*invoiced.Notification] {
+	return c.resource().IterateQueryCtx(ctx, query)
+}
+
+func (c *Client) Count() (int64, error) {
+	return c.CountCtx(context.Background())
+}
 
-	return notifications, nil
+func (c *Client) CountCtx(ctx context.Context) (int64, error) {
+	return c.resource().CountCtx(ctx)
 }
@@ -0,0 +1,150 @@
+package invoiced
+
+import (
+	"crypto/tls"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Option customizes an Api created with New.
+type Option func(*options)
+
+type options struct {
+	httpClient          *http.Client
+	transport           http.RoundTripper
+	baseUrl             string
+	timeout             time.Duration
+	userAgentSuffix     string
+	proxy               func(*http.Request) (*url.URL, error)
+	tlsConfig           *tls.Config
+	retryPolicy         *RetryPolicy
+	autoIdempotencyKeys bool
+	middleware          []Middleware
+	logger              *slog.Logger
+	redactedFields      []string
+	instrumentation     Instrumentation
+	rateLimiter         *RateLimiter
+	pageConcurrency     int
+	validation          bool
+}
+
+// WithHTTPClient makes the Api send requests with the given client. The
+// client is copied, so later options such as WithTimeout do not modify it.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = client
+	}
+}
+
+// WithTransport sets the RoundTripper used to send requests.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(o *options) {
+		o.transport = transport
+	}
+}
+
+// WithBaseUrl points the Api at another host, e.g. a local mock server.
+// It takes precedence over the sandbox flag.
+func WithBaseUrl(baseUrl string) Option {
+	return func(o *options) {
+		o.baseUrl = baseUrl
+	}
+}
+
+// WithTimeout limits the time of a single HTTP exchange, including reading
+// the response body.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithUserAgentSuffix appends the given text to the User-Agent header, which
+// helps identify the integration making the requests.
+func WithUserAgentSuffix(suffix string) Option {
+	return func(o *options) {
+		o.userAgentSuffix = suffix
+	}
+}
+
+// WithProxy sends every request through the given proxy. It only applies
+// when the transport is an *http.Transport.
+func WithProxy(proxyUrl *url.URL) Option {
+	return func(o *options) {
+		o.proxy = http.ProxyURL(proxyUrl)
+	}
+}
+
+// WithTLSConfig sets the TLS configuration of the transport. It only
+// applies when the transport is an *http.Transport.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(o *options) {
+		o.tlsConfig = config
+	}
+}
+
+// WithRetryPolicy is the option form of Api.SetRetryPolicy.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(o *options) {
+		o.retryPolicy = policy
+	}
+}
+
+// WithAutoIdempotencyKeys is the option form of Api.SetAutoIdempotencyKeys.
+func WithAutoIdempotencyKeys(enabled bool) Option {
+	return func(o *options) {
+		o.autoIdempotencyKeys = enabled
+	}
+}
+
+func (o *options) buildHTTPClient() *http.Client {
+	client := new(http.Client)
+	if o.httpClient != nil {
+		*client = *o.httpClient
+	}
+
+	if o.transport != nil {
+		client.Transport = o.transport
+	}
+
+	if o.proxy != nil || o.tlsConfig != nil {
+		transport := client.Transport
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
+
+		if t, ok := transport.(*http.Transport); ok {
+			t = t.Clone()
+
+			if o.proxy != nil {
+				t.Proxy = o.proxy
+			}
+
+			if o.tlsConfig != nil {
+				t.TLSClientConfig = o.tlsConfig
+			}
+
+			client.Transport = t
+		}
+	}
+
+	if o.timeout > 0 {
+		client.Timeout = o.timeout
+	}
+
+	return client
+}
+
+// HTTPClient returns the client the Api sends requests with.
+func (c *Api) HTTPClient() *http.Client {
+	return c.client
+}
+
+// SetHTTPClient is the setter form of WithHTTPClient. Unlike the option, it
+// does not copy the client, so several Api instances can share one client
+// and its connections.
+func (c *Api) SetHTTPClient(client *http.Client) {
+	c.client = client
+}
@@ -0,0 +1,134 @@
+package invoiced
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Invoiced/invoiced-go/v2/invdmockserver"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestNewDefaults(t *testing.T) {
+	production := New("key", false)
+	if production.baseUrl != productionUrl {
+		t.Fatal("Unexpected base URL", production.baseUrl)
+	}
+
+	sandbox := New("key", true)
+	if sandbox.baseUrl != sandboxUrl || !sandbox.Sandbox {
+		t.Fatal("Unexpected base URL", sandbox.baseUrl)
+	}
+
+	if production.userAgent() != "Invoiced Go/"+version {
+		t.Fatal("Unexpected User-Agent", production.userAgent())
+	}
+}
+
+func TestNewWithOptions(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(false,
+		invdmockserver.ScriptedResponse{Status: 200, Body: &Customer{Id: 1234}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	client := New("key", true,
+		WithBaseUrl(server.URL+"/"),
+		WithTimeout(5*time.Second),
+		WithUserAgentSuffix("acme-sync/1.2"),
+		WithRetryPolicy(DefaultRetryPolicy()),
+		WithAutoIdempotencyKeys(true),
+	)
+
+	if client.client.Timeout != 5*time.Second {
+		t.Fatal("Timeout was not applied")
+	}
+
+	if client.retryPolicy == nil || !client.autoIdempotencyKeys {
+		t.Fatal("Retry options were not applied")
+	}
+
+	err = client.Create("/customers", &CustomerRequest{Name: String("Jane")}, new(Customer))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request := server.Requests()[0]
+
+	if request.Url != "/customers" {
+		t.Fatal("Base URL was not applied", request.Url)
+	}
+
+	if request.Header.Get("User-Agent") != "Invoiced Go/"+version+" acme-sync/1.2" {
+		t.Fatal("Unexpected User-Agent", request.Header.Get("User-Agent"))
+	}
+}
+
+func TestWithTransport(t *testing.T) {
+	var seen *http.Request
+
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		seen = req
+		return &http.Response{
+			StatusCode: 200,
+			Header:     http.Header{"X-Total-Count": []string{"42"}},
+			Body:       http.NoBody,
+		}, nil
+	})
+
+	client := New("key", false, WithTransport(transport))
+
+	count, err := client.Count("/invoices")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 42 || seen == nil || seen.URL.String() != productionUrl+"/invoices" {
+		t.Fatal("Request did not go through the transport")
+	}
+}
+
+func TestWithHTTPClientIsNotModified(t *testing.T) {
+	httpClient := &http.Client{Timeout: time.Minute}
+
+	proxyUrl, _ := url.Parse("http://proxy.local:3128")
+	client := New("key", false,
+		WithHTTPClient(httpClient),
+		WithTimeout(time.Second),
+		WithProxy(proxyUrl),
+		WithTLSConfig(&tls.Config{MinVersion: tls.VersionTLS12}),
+	)
+
+	if httpClient.Timeout != time.Minute || httpClient.Transport != nil {
+		t.Fatal("The supplied http.Client should not be modified")
+	}
+
+	transport, ok := client.client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatal("Expected an *http.Transport")
+	}
+
+	if transport == http.DefaultTransport {
+		t.Fatal("The default transport should not be modified")
+	}
+
+	if transport.TLSClientConfig.MinVersion != tls.VersionTLS12 {
+		t.Fatal("TLS config was not applied")
+	}
+
+	req, _ := http.NewRequest("GET", productionUrl, nil)
+	proxy, err := transport.Proxy(req)
+	if err != nil || !strings.Contains(proxy.String(), "proxy.local") {
+		t.Fatal("Proxy was not applied", proxy, err)
+	}
+}
@@ -1,94 +1,117 @@
 package payment
 
 import (
+	"context"
 	"github.com/Invoiced/invoiced-go/v2"
+	"io"
 	"strconv"
+	"time"
 )
 
 type Client struct {
 	*invoiced.Api
 }
 
+func (c *Client) resource() invoiced.Resource[invoiced.Payment, invoiced.PaymentRequest, int64] {
+	return invoiced.NewResource[invoiced.Payment, invoiced.PaymentRequest, int64](c.Api, "payment", "/payments")
+}
+
 func (c *Client) Create(request *invoiced.PaymentRequest) (*invoiced.Payment, error) {
-	resp := new(invoiced.Payment)
-	err := c.Api.Create("/payments", request, resp)
-	return resp, err
+	return c.CreateCtx(context.Background(), request)
+}
+
+func (c *Client) CreateCtx(ctx context.Context, request *invoiced.PaymentRequest) (*invoiced.Payment, error) {
+	return c.resource().CreateCtx(ctx, request)
 }
 
 func (c *Client) Retrieve(id int64) (*invoiced.Payment, error) {
-	resp := new(invoiced.Payment)
-	_, err := c.Api.Get("/payments/"+strconv.FormatInt(id, 10), resp)
-	return resp, err
+	return c.RetrieveCtx(context.Background(), id)
+}
+
+func (c *Client) RetrieveCtx(ctx context.Context, id int64) (*invoiced.Payment, error) {
+	return c.resource().RetrieveCtx(ctx, id)
 }
 
 func (c *Client) RetrieveAccountingSyncStatus(id int64) (*invoiced.AccountingSyncStatus, error) {
+	return c.RetrieveAccountingSyncStatusCtx(context.Background(), id)
+}
+
+func (c *Client) RetrieveAccountingSyncStatusCtx(ctx context.Context, id int64) (_ *invoiced.AccountingSyncStatus, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "payment.retrieve_accounting_sync_status")
+	defer func() { op.End(err) }()
+
 	resp := new(invoiced.AccountingSyncStatus)
-	_, err := c.Api.Get("/payments/"+strconv.FormatInt(id, 10)+ "/accounting_sync_status", resp)
+	_, err = c.Api.GetCtx(ctx, "/payments/"+strconv.FormatInt(id, 10)+"/accounting_sync_status", resp)
 	return resp, err
 }
 
 func (c *Client) Update(id int64, request *invoiced.PaymentRequest) (*invoiced.Payment, error) {
-	resp := new(invoiced.Payment)
-	err := c.Api.Update("/payments/"+strconv.FormatInt(id, 10), request, resp)
-	return resp, err
+	return c.UpdateCtx(context.Background(), id, request)
 }
 
-func (c *Client) Delete(id int64) error {
-	return c.Api.Delete("/payments/" + strconv.FormatInt(id, 10))
+func (c *Client) UpdateCtx(ctx context.Context, id int64, request *invoiced.PaymentRequest) (*invoiced.Payment, error) {
+	return c.resource().UpdateCtx(ctx, id, request)
 }
 
-func (c *Client) Count() (int64, error) {
-	return c.Api.Count("/payments")
+func (c *Client) Delete(id int64) error {
+	return c.DeleteCtx(context.Background(), id)
 }
 
-func (c *Client) ListAll(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Payments, error) {
-	endpoint := invoiced.AddFilterAndSort("/payments", filter, sort)
-	payments := make(invoiced.Payments, 0)
+func (c *Client) DeleteCtx(ctx context.Context, id int64) error {
+	return c.resource().DeleteCtx(ctx, id)
+}
 
-NEXT:
-	tmpPayments := make(invoiced.Payments, 0)
+func (c *Client) Count() (int64, error) {
+	return c.CountCtx(context.Background())
+}
 
-	endpoint, err := c.Api.Get(endpoint, &tmpPayments)
+func (c *Client) CountCtx(ctx context.Context) (int64, error) {
+	return c.resource().CountCtx(ctx)
+}
 
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) ListAll(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Payments, error) {
+	return c.ListAllCtx(context.Background(), filter, sort)
+}
 
-	payments = append(payments, tmpPayments...)
+func (c *Client) ListAllCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Payments, error) {
+	return c.resource().ListAllCtx(ctx, filter, sort)
+}
 
-	if endpoint != "" {
-		goto NEXT
-	}
+func (c *Client) Iterate(filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.Payment] {
+	return c.IterateCtx(context.Background(), filter, sort)
+}
 
-	return payments, nil
+func (c *Client) IterateCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.Payment] {
+	return c.resource().IterateCtx(ctx, filter, sort)
 }
 
+// ListAllMetadataFilter is a shorthand for ListAllQuery with both filters
+// added by Query.WithFilter.
 func (c *Client) ListAllMetadataFilter(filter *invoiced.Filter, metaFilter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Payments, error) {
-	endpoint, err := invoiced.AddFilterAndMetaFilterAndSort("/payments", filter,metaFilter, sort)
-	if err != nil {
-		return nil, err
-	}
-	payments := make(invoiced.Payments, 0)
-
-NEXT:
-	tmpPayments := make(invoiced.Payments, 0)
+	return c.ListAllMetadataFilterCtx(context.Background(), filter, metaFilter, sort)
+}
 
-	endpoint, err = c.Api.Get(endpoint, &tmpPayments)
+func (c *Client) ListAllMetadataFilterCtx(ctx context.Context, filter *invoiced.Filter, metaFilter *invoiced.Filter, sort *invoiced.Sort) (_ invoiced.Payments, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "payment.list_all_metadata_filter")
+	defer func() { op.End(err) }()
 
+	endpoint, err := invoiced.AddFilterAndMetaFilterAndSort("/payments", filter, metaFilter, sort)
 	if err != nil {
 		return nil, err
 	}
+	return invoiced.CollectAll[invoiced.Payments](ctx, c.Api, endpoint)
+}
 
-	payments = append(payments, tmpPayments...)
-
-	if endpoint != "" {
-		goto NEXT
-	}
-
-	return payments, nil
+// ListAllStartEndDate is a shorthand for ListAllQuery with Query.StartDate
+// and Query.EndDate.
+func (c *Client) ListAllStartEndDate(filter *invoiced.Filter, sort *invoiced.Sort, startDate, endDate int64) (invoiced.Payments, error) {
+	return c.ListAllStartEndDateCtx(context.Background(), filter, sort, startDate, endDate)
 }
 
-func (c *Client) ListAllStartEndDate(filter *invoiced.Filter, sort *invoiced.Sort,startDate,endDate int64) (invoiced.Payments, error) {
+func (c *Client) ListAllStartEndDateCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort, startDate, endDate int64) (_ invoiced.Payments, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "payment.list_all_start_end_date")
+	defer func() { op.End(err) }()
+
 	endpoint := "/payments"
 
 	endpoint = invoiced.AddFilterAndSort(endpoint, filter, sort)
@@ -103,27 +126,29 @@ func (c *Client) ListAllStartEndDate(filter *invoiced.Filter, sort *invoiced.Sor
 		endpoint = invoiced.AddQueryParameter(endpoint, "end_date", endDateString)
 	}
 
-	payments := make(invoiced.Payments, 0)
-
-NEXT:
-	tmpPayments := make(invoiced.Payments, 0)
-
-	endpoint, err := c.Api.Get(endpoint, &tmpPayments)
-
-	if err != nil {
-		return nil, err
-	}
+	return invoiced.CollectAll[invoiced.Payments](ctx, c.Api, endpoint)
+}
 
-	payments = append(payments, tmpPayments...)
+// ListAllStartEndTime is a shorthand for ListAllQuery with Query.DateRange.
+func (c *Client) ListAllStartEndTime(filter *invoiced.Filter, sort *invoiced.Sort, start, end time.Time) (invoiced.Payments, error) {
+	return c.ListAllStartEndTimeCtx(context.Background(), filter, sort, start, end)
+}
 
-	if endpoint != "" {
-		goto NEXT
-	}
+func (c *Client) ListAllStartEndTimeCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort, start, end time.Time) (invoiced.Payments, error) {
+	return c.ListAllStartEndDateCtx(ctx, filter, sort, invoiced.Unix(start), invoiced.Unix(end))
+}
 
-	return payments, nil
+// ListAllUpdatedBeforeAfterExpand is a shorthand for ListAllQuery with
+// Query.UpdatedAfter, Query.UpdatedBefore, Query.WithExpand and
+// Query.Include("applied_to").
+func (c *Client) ListAllUpdatedBeforeAfterExpand(filter *invoiced.Filter, sort *invoiced.Sort, expand *invoiced.Expand, updatedAfter, updatedBefore int64) (invoiced.Payments, error) {
+	return c.ListAllUpdatedBeforeAfterExpandCtx(context.Background(), filter, sort, expand, updatedAfter, updatedBefore)
 }
 
-func (c *Client) ListAllUpdatedBeforeAfterExpand(filter *invoiced.Filter, sort *invoiced.Sort,expand *invoiced.Expand,updatedAfter,updatedBefore int64) (invoiced.Payments, error) {
+func (c *Client) ListAllUpdatedBeforeAfterExpandCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort, expand *invoiced.Expand, updatedAfter, updatedBefore int64) (_ invoiced.Payments, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "payment.list_all_updated_before_after_expand")
+	defer func() { op.End(err) }()
+
 	endpoint := "/payments"
 
 	endpoint = invoiced.AddFilterAndSort(endpoint, filter, sort)
@@ -144,27 +169,20 @@ func (c *Client) ListAllUpdatedBeforeAfterExpand(filter *invoiced.Filter, sort *
 
 	endpoint = invoiced.AddQueryParameter(endpoint, "include", "applied_to")
 
-	payments := make(invoiced.Payments, 0)
-
-NEXT:
-	tmpPayments := make(invoiced.Payments, 0)
-
-	endpoint, err := c.Api.Get(endpoint, &tmpPayments)
-
-	if err != nil {
-		return nil, err
-	}
-
-	payments = append(payments, tmpPayments...)
-
-	if endpoint != "" {
-		goto NEXT
-	}
+	return invoiced.CollectAll[invoiced.Payments](ctx, c.Api, endpoint)
+}
 
-	return payments, nil
+// ListAllStartEndDateExpand is a shorthand for ListAllQuery with
+// Query.StartDate, Query.EndDate, Query.WithExpand and
+// Query.Include("applied_to").
+func (c *Client) ListAllStartEndDateExpand(filter *invoiced.Filter, sort *invoiced.Sort, expand *invoiced.Expand, startDate, endDate int64) (invoiced.Payments, error) {
+	return c.ListAllStartEndDateExpandCtx(context.Background(), filter, sort, expand, startDate, endDate)
 }
 
-func (c *Client) ListAllStartEndDateExpand(filter *invoiced.Filter, sort *invoiced.Sort,expand *invoiced.Expand,startDate,endDate int64) (invoiced.Payments, error) {
+func (c *Client) ListAllStartEndDateExpandCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort, expand *invoiced.Expand, startDate, endDate int64) (_ invoiced.Payments, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "payment.list_all_start_end_date_expand")
+	defer func() { op.End(err) }()
+
 	endpoint := "/payments"
 
 	endpoint = invoiced.AddFilterAndSort(endpoint, filter, sort)
@@ -185,40 +203,66 @@ func (c *Client) ListAllStartEndDateExpand(filter *invoiced.Filter, sort *invoic
 
 	endpoint = invoiced.AddQueryParameter(endpoint, "include", "applied_to")
 
-	payments := make(invoiced.Payments, 0)
-
-NEXT:
-	tmpPayments := make(invoiced.Payments, 0)
+	return invoiced.CollectAll[invoiced.Payments](ctx, c.Api, endpoint)
+}
 
-	endpoint, err := c.Api.Get(endpoint, &tmpPayments)
+func (c *Client) List(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Payments, string, error) {
+	return c.ListCtx(context.Background(), filter, sort)
+}
 
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) ListCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Payments, string, error) {
+	return c.resource().ListCtx(ctx, filter, sort)
+}
 
-	payments = append(payments, tmpPayments...)
+func (c *Client) ListQuery(query *invoiced.Query) (invoiced.Payments, string, error) {
+	return c.ListQueryCtx(context.Background(), query)
+}
 
-	if endpoint != "" {
-		goto NEXT
-	}
+func (c *Client) ListQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.Payments, string, error) {
+	return c.resource().ListQueryCtx(ctx, query)
+}
 
-	return payments, nil
+func (c *Client) ListAllQuery(query *invoiced.Query) (invoiced.Payments, error) {
+	return c.ListAllQueryCtx(context.Background(), query)
 }
 
-func (c *Client) List(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Payments, string, error) {
-	endpoint := invoiced.AddFilterAndSort("/payments", filter, sort)
-	payments := make(invoiced.Payments, 0)
+func (c *Client) ListAllQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.Payments, error) {
+	return c.resource().ListAllQueryCtx(ctx, query)
+}
 
-	nextEndpoint, err := c.Api.Get(endpoint, &payments)
-	if err != nil {
-		return nil, "", err
-	}
+func (c *Client) IterateQuery(query *invoiced.Query) *invoiced.Iter[*invoiced.Payment] {
+	return c.IterateQueryCtx(context.Background(), query)
+}
 
-	return payments, nextEndpoint, nil
+func (c *Client) IterateQueryCtx(ctx context.Context, query *invoiced.Query) *invoiced.Iter[*invoiced.Payment] {
+	return c.resource().IterateQueryCtx(ctx, query)
 }
 
 func (c *Client) SendReceipt(id int64, request *invoiced.SendEmailRequest) error {
+	return c.SendReceiptCtx(context.Background(), id, request)
+}
+
+func (c *Client) SendReceiptCtx(ctx context.Context, id int64, request *invoiced.SendEmailRequest) (err error) {
+	ctx, op := c.Api.StartOperation(ctx, "payment.send_receipt")
+	defer func() { op.End(err) }()
+
 	endpoint := "/payments/" + strconv.FormatInt(id, 10) + "/emails"
 
-	return c.Api.Create(endpoint, request, nil)
+	return c.Api.CreateCtx(ctx, endpoint, request, nil)
+}
+
+func (c *Client) DownloadPDF(id int64, w io.Writer) (*invoiced.Download, error) {
+	return c.DownloadPDFCtx(context.Background(), id, w)
+}
+
+func (c *Client) DownloadPDFCtx(ctx context.Context, id int64, w io.Writer) (_ *invoiced.Download, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "payment.download_pdf")
+	defer func() { op.End(err) }()
+
+	payment := new(invoiced.Payment)
+	if _, err = c.Api.GetCtx(ctx, c.resource().Endpoint(id), payment); err != nil {
+		return nil, err
+	}
+
+	return c.Api.DownloadCtx(ctx, payment.PdfUrl, w)
 }
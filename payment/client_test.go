@@ -15,7 +15,7 @@ func TestPaymentCreate(t *testing.T) {
 	mockPaymentResponse := new(invoiced.Payment)
 	mockPaymentResponse.Id = mockPaymentResponseID
 	mockPaymentResponse.CreatedAt = time.Now().UnixNano()
-	mockPaymentResponse.Customer = 234112
+	mockPaymentResponse.Customer.Id = 234112
 	mockPaymentResponse.Reference = "234"
 
 	server, err := invdmockserver.New(200, mockPaymentResponse, "json", true)
@@ -70,7 +70,7 @@ func TestPaymentUpdate(t *testing.T) {
 	mockPaymentResponse := new(invoiced.Payment)
 	mockPaymentResponse.Id = mockPaymentResponseID
 	mockPaymentResponse.CreatedAt = time.Now().UnixNano()
-	mockPaymentResponse.Customer = 234112
+	mockPaymentResponse.Customer.Id = 234112
 	mockPaymentResponse.Reference = "234"
 	mockPaymentResponse.Amount = 42
 
@@ -170,7 +170,7 @@ func TestPaymentRetrieve(t *testing.T) {
 	mockPaymentResponseID := int64(1523)
 	mockPaymentResponse := new(invoiced.Payment)
 	mockPaymentResponse.Id = mockPaymentResponseID
-	mockPaymentResponse.Customer = 234112
+	mockPaymentResponse.Customer.Id = 234112
 	mockPaymentResponse.Reference = "234"
 	mockPaymentResponse.CreatedAt = time.Now().UnixNano()
 
@@ -2,7 +2,8 @@ package invoiced
 
 import (
 	"encoding/json"
-	"strconv"
+	"fmt"
+	"time"
 )
 
 type PaymentRequest struct {
@@ -28,29 +29,80 @@ type PaymentItemRequest struct {
 	Type         *string  `json:"type,omitempty"`
 }
 
+func (r *PaymentRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+
+	var f fieldErrors
+	r.validate(&f)
+
+	return f.err()
+}
+
+// ValidateCreate also requires the amount and the customer.
+func (r *PaymentRequest) ValidateCreate() error {
+	if r == nil {
+		return nil
+	}
+
+	var f fieldErrors
+	f.required("amount", r.Amount != nil)
+	f.required("customer", r.Customer != nil)
+	r.validate(&f)
+
+	return f.err()
+}
+
+func (r *PaymentRequest) validate(f *fieldErrors) {
+	f.id("customer", r.Customer)
+	f.currency("currency", r.Currency)
+	f.nonNegative("amount", r.Amount)
+
+	applied := NewMoney(0, "")
+	for i, item := range r.AppliedTo {
+		path := fmt.Sprintf("applied_to[%d]", i)
+		if item == nil {
+			f.add(path, "must not be null")
+			continue
+		}
+
+		f.nonNegative(path+".amount", item.Amount)
+		f.id(path+".invoice", item.Invoice)
+		f.id(path+".credit_note", item.CreditNote)
+		f.id(path+".estimate", item.Estimate)
+
+		if item.Amount != nil && isFinite(*item.Amount) {
+			applied = applied.Add(MoneyFromFloat(*item.Amount, ""))
+		}
+	}
+
+	if r.Amount != nil && isFinite(*r.Amount) && applied.Cmp(MoneyFromFloat(*r.Amount, "")) > 0 {
+		f.add("applied_to", "applies %.2f, more than the payment amount of %.2f", applied.Float64(), *r.Amount)
+	}
+}
+
 type Payment struct {
-	Amount       float64                `json:"amount"`
-	AppliedTo    []PaymentItem          `json:"applied_to"`
-	Balance      float64                `json:"balance"`
-	Charge       *Charge                `json:"charge"`
-	CreatedAt    int64                  `json:"created_at"`
-	Currency     string                 `json:"currency"`
-	Customer     int64                  `json:"-"`
-	CustomerFull *Customer              `json:"-"`
-	CustomerRaw  json.RawMessage        `json:"customer"`
-	Date         int64                  `json:"date"`
-	Id           int64                  `json:"id"`
-	Matched      bool                   `json:"matched"`
-	Metadata     map[string]interface{} `json:"metadata"`
-	Method       string                 `json:"method"`
-	Notes        string                 `json:"notes"`
-	Object       string                 `json:"object"`
-	PdfUrl       string                 `json:"pdf_url"`
-	Reference    string                 `json:"reference"`
-	Source       string                 `json:"source"`
-	Status       string                 `json:"status"`
-	UpdatedAt    int64                  `json:"updated_at"`
-	Voided       bool                   `json:"voided"`
+	Amount    float64                     `json:"amount"`
+	AppliedTo []PaymentItem               `json:"applied_to"`
+	Balance   float64                     `json:"balance"`
+	Charge    *Charge                     `json:"charge"`
+	CreatedAt int64                       `json:"created_at"`
+	Currency  string                      `json:"currency"`
+	Customer  Expandable[Customer, int64] `json:"customer"`
+	Date      int64                       `json:"date"`
+	Id        int64                       `json:"id"`
+	Matched   bool                        `json:"matched"`
+	Metadata  map[string]interface{}      `json:"metadata"`
+	Method    PaymentMethod               `json:"method"`
+	Notes     string                      `json:"notes"`
+	Object    string                      `json:"object"`
+	PdfUrl    string                      `json:"pdf_url"`
+	Reference string                      `json:"reference"`
+	Source    string                      `json:"source"`
+	Status    string                      `json:"status"`
+	UpdatedAt int64                       `json:"updated_at"`
+	Voided    bool                        `json:"voided"`
 }
 
 type PaymentItem struct {
@@ -64,41 +116,45 @@ type PaymentItem struct {
 
 type Payments []*Payment
 
-func (i *Payment) UnmarshalJSON(data []byte) error {
-	type payment2 Payment
+func (i *Payment) String() string {
+	b, _ := json.MarshalIndent(i, "", "    ")
 
-	if err := json.Unmarshal(data, (*payment2)(i)); err != nil {
-		return err
-	}
+	return string(b)
+}
 
-	rj := i.CustomerRaw
+func (i *Payment) AmountMoney() (Money, error) {
+	return CheckedMoneyFromFloat(i.Amount, i.Currency)
+}
 
-	i.Customer, _ = strconv.ParseInt(string(rj), 10, 64)
-	customer := new(Customer)
+func (i *Payment) BalanceMoney() (Money, error) {
+	return CheckedMoneyFromFloat(i.Balance, i.Currency)
+}
 
-	err := json.Unmarshal(rj, customer)
+func (i *PaymentItem) AmountMoney(currency string) (Money, error) {
+	return CheckedMoneyFromFloat(i.Amount, currency)
+}
 
-	if err == nil {
-		i.CustomerFull = customer
-		i.Customer = customer.Id
+// SetAmount sets the amount of the payment, and its currency unless the
+// request already has one.
+func (r *PaymentRequest) SetAmount(amount Money) {
+	r.Amount = Float64(amount.Float64())
+	if r.Currency == nil && amount.Currency() != "" {
+		r.Currency = String(amount.Currency())
 	}
-
-	return nil
 }
 
-func (i *Payment) MarshalJSON() ([]byte, error) {
-	type payment2 Payment
-	i2 := (*payment2)(i)
-
-	if i2.Customer > 0 {
-		i2.CustomerRaw = []byte(strconv.FormatInt(i2.Customer, 10))
-	}
+func (r *PaymentItemRequest) SetAmount(amount Money) {
+	r.Amount = Float64(amount.Float64())
+}
 
-	return json.Marshal(i2)
+func (i *Payment) CreatedAtTime() time.Time {
+	return Time(i.CreatedAt)
 }
 
-func (i *Payment) String() string {
-	b, _ := json.MarshalIndent(i, "", "    ")
+func (i *Payment) DateTime() time.Time {
+	return Time(i.Date)
+}
 
-	return string(b)
+func (i *Payment) UpdatedAtTime() time.Time {
+	return Time(i.UpdatedAt)
 }
@@ -37,7 +37,7 @@ func TestUnMarshalPaymentObject(t *testing.T) {
 		t.Fatal("Client has incorrect periodstart")
 	}
 
-	if so.Customer != 15460 {
+	if so.Customer.Id != 15460 {
 		t.Fatal("Client has incorrect periodstart")
 	}
 
@@ -1,6 +1,7 @@
 package plan
 
 import (
+	"context"
 	"github.com/Invoiced/invoiced-go/v2"
 	"strings"
 )
@@ -9,35 +10,63 @@ type Client struct {
 	*invoiced.Api
 }
 
+func (c *Client) resource() invoiced.Resource[invoiced.Plan, invoiced.PlanRequest, string] {
+	return invoiced.NewResource[invoiced.Plan, invoiced.PlanRequest, string](c.Api, "plan", "/plans")
+}
+
 func (c *Client) Create(request *invoiced.PlanRequest) (*invoiced.Plan, error) {
-	resp := new(invoiced.Plan)
-	err := c.Api.Create("/plans", request, resp)
-	return resp, err
+	return c.CreateCtx(context.Background(), request)
+}
+
+func (c *Client) CreateCtx(ctx context.Context, request *invoiced.PlanRequest) (*invoiced.Plan, error) {
+	return c.resource().CreateCtx(ctx, request)
 }
 
 func (c *Client) Retrieve(id string) (*invoiced.Plan, error) {
-	resp := new(invoiced.Plan)
-	_, err := c.Api.Get("/plans/"+id, resp)
-	return resp, err
+	return c.RetrieveCtx(context.Background(), id)
+}
+
+func (c *Client) RetrieveCtx(ctx context.Context, id string) (*invoiced.Plan, error) {
+	return c.resource().RetrieveCtx(ctx, id)
 }
 
 func (c *Client) RetrieveWithSubNumber(id string) (*invoiced.Plan, error) {
+	return c.RetrieveWithSubNumberCtx(context.Background(), id)
+}
+
+func (c *Client) RetrieveWithSubNumberCtx(ctx context.Context, id string) (_ *invoiced.Plan, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "plan.retrieve_with_sub_number")
+	defer func() { op.End(err) }()
+
 	resp := new(invoiced.Plan)
-	_, err := c.Api.Get("/plans/"+id+"?include=num_subscriptions", resp)
+	_, err = c.Api.GetCtx(ctx, "/plans/"+id+"?include=num_subscriptions", resp)
 	return resp, err
 }
 
 func (c *Client) Update(id string, request *invoiced.PlanRequest) (*invoiced.Plan, error) {
-	resp := new(invoiced.Plan)
-	err := c.Api.Update("/plans/"+id, request, resp)
-	return resp, err
+	return c.UpdateCtx(context.Background(), id, request)
+}
+
+func (c *Client) UpdateCtx(ctx context.Context, id string, request *invoiced.PlanRequest) (*invoiced.Plan, error) {
+	return c.resource().UpdateCtx(ctx, id, request)
 }
 
 func (c *Client) Delete(id string) error {
-	return c.Api.Delete("/plans/" + id)
+	return c.DeleteCtx(context.Background(), id)
+}
+
+func (c *Client) DeleteCtx(ctx context.Context, id string) error {
+	return c.resource().DeleteCtx(ctx, id)
 }
 
 func (c *Client) ListAllSubNumber(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Plans, error) {
+	return c.ListAllSubNumberCtx(context.Background(), filter, sort)
+}
+
+func (c *Client) ListAllSubNumberCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (_ invoiced.Plans, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "plan.list_all_sub_number")
+	defer func() { op.End(err) }()
+
 	endpoint := invoiced.AddFilterAndSort("/plans", filter, sort)
 
 	if strings.Contains(endpoint, "?") {
@@ -46,45 +75,61 @@ func (c *Client) ListAllSubNumber(filter *invoiced.Filter, sort *invoiced.Sort)
 		endpoint = endpoint + "?include=num_subscriptions"
 	}
 
-	plans := make(invoiced.Plans, 0)
+	return invoiced.CollectAll[invoiced.Plans](ctx, c.Api, endpoint)
+}
 
-NEXT:
-	tmpPlans := make(invoiced.Plans, 0)
+func (c *Client) ListAll(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Plans, error) {
+	return c.ListAllCtx(context.Background(), filter, sort)
+}
 
-	endpoint, err := c.Api.Get(endpoint, &tmpPlans)
+func (c *Client) ListAllCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Plans, error) {
+	return c.resource().ListAllCtx(ctx, filter, sort)
+}
 
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) Iterate(filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.Plan] {
+	return c.IterateCtx(context.Background(), filter, sort)
+}
 
-	plans = append(plans, tmpPlans...)
+func (c *Client) IterateCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.Plan] {
+	return c.resource().IterateCtx(ctx, filter, sort)
+}
 
-	if endpoint != "" {
-		goto NEXT
-	}
+func (c *Client) List(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Plans, string, error) {
+	return c.ListCtx(context.Background(), filter, sort)
+}
 
-	return plans, nil
+func (c *Client) ListCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Plans, string, error) {
+	return c.resource().ListCtx(ctx, filter, sort)
 }
 
-func (c *Client) ListAll(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Plans, error) {
-	endpoint := invoiced.AddFilterAndSort("/plans", filter, sort)
+func (c *Client) ListQuery(query *invoiced.Query) (invoiced.Plans, string, error) {
+	return c.ListQueryCtx(context.Background(), query)
+}
 
-	plans := make(invoiced.Plans, 0)
+func (c *Client) ListQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.Plans, string, error) {
+	return c.resource().ListQueryCtx(ctx, query)
+}
 
-NEXT:
-	tmpPlans := make(invoiced.Plans, 0)
+func (c *Client) ListAllQuery(query *invoiced.Query) (invoiced.Plans, error) {
+	return c.ListAllQueryCtx(context.Background(), query)
+}
 
-	endpoint, err := c.Api.Get(endpoint, &tmpPlans)
+func (c *Client) ListAllQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.Plans, error) {
+	return c.resource().ListAllQueryCtx(ctx, query)
+}
 
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) IterateQuery(query *invoiced.Query) *invoiced.Iter[*invoiced.Plan] {
+	return c.IterateQueryCtx(context.Background(), query)
+}
 
-	plans = append(plans, tmpPlans...)
+func (c *Client) IterateQueryCtx(ctx context.Context, query *invoiced.Query) *invoiced.Iter[*invoiced.Plan] {
+	return c.resource().IterateQueryCtx(ctx, query)
+}
 
-	if endpoint != "" {
-		goto NEXT
-	}
+func (c *Client) Count() (int64, error) {
+	return c.CountCtx(context.Background())
+}
 
-	return plans, nil
+func (c *Client) CountCtx(ctx context.Context) (int64, error) {
+	return c.resource().CountCtx(ctx)
 }
@@ -25,14 +25,14 @@ type Plan struct {
 	CreatedAt             int64                  `json:"created_at"`
 	Currency              string                 `json:"currency"`
 	Id                    string                 `json:"id"`
-	Interval              string                 `json:"interval"`
+	Interval              Interval               `json:"interval"`
 	IntervalCount         float64                `json:"interval_count"`
 	Item                  string                 `json:"catalog_item"`
 	Metadata              map[string]interface{} `json:"metadata"`
 	Name                  string                 `json:"name"`
 	NumberOfSubscriptions *int64                 `json:"num_subscriptions"`
 	Object                string                 `json:"object"`
-	PricingMode           string                 `json:"pricing_mode"`
+	PricingMode           PricingMode            `json:"pricing_mode"`
 	QuantityType          string                 `json:"quantity_type"`
 	Tiers                 []Tier                 `json:"tier"`
 	UpdatedAt             int64                  `json:"updated_at"`
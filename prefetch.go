@@ -0,0 +1,191 @@
+package invoiced
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// WithConcurrentPages lets ListAll calls fetch up to n pages at once. Once
+// the first page is in, the remaining pages are requested by number, using
+// the X-Total-Count header to know how many there are. When the API does not
+// report a total, pages are followed one after another through the Link
+// header as usual. Either way items are returned in the order of the server.
+func WithConcurrentPages(n int) Option {
+	return func(o *options) {
+		o.pageConcurrency = n
+	}
+}
+
+// SetConcurrentPages is the setter form of WithConcurrentPages. A value of 1
+// or less restores sequential paging.
+func (c *Api) SetConcurrentPages(n int) {
+	c.pageConcurrency = n
+}
+
+func collectConcurrently[E any](ctx context.Context, api *Api, endpoint string) ([]E, error) {
+	it := NewIter[E](ctx, api, endpoint)
+
+	first, ok := it.NextPage()
+	if !ok {
+		if it.Err() != nil {
+			return nil, it.Err()
+		}
+
+		return make([]E, 0), nil
+	}
+
+	items := append(make([]E, 0, len(first)), first...)
+
+	if it.NextEndpoint() == "" {
+		return items, nil
+	}
+
+	endpoints := pageEndpoints(it.NextEndpoint(), it.header.Get("X-Total-Count"), len(first))
+	if endpoints == nil {
+		rest, err := it.Collect()
+		if err != nil {
+			return nil, err
+		}
+
+		return append(items, rest...), nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		lastNext string
+	)
+
+	pages := make([][]E, len(endpoints))
+	sem := make(chan struct{}, api.pageConcurrency)
+
+	for i, pageEndpoint := range endpoints {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			page := make([]E, 0)
+
+			next, _, err := api.getPage(ctx, pageEndpoint, &page)
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				mu.Unlock()
+				return
+			}
+
+			pages[i] = page
+
+			if i == len(endpoints)-1 {
+				lastNext = next
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, page := range pages {
+		items = append(items, page...)
+	}
+
+	// Items created while listing can push the list past the count read from
+	// the first page, in which case the last page still links to a next one.
+	if lastNext != "" {
+		rest, err := NewIter[E](ctx, api, lastNext).Collect()
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, rest...)
+	}
+
+	return items, nil
+}
+
+// pageEndpoints returns the endpoints of every page from next to the last
+// page, or nil when they cannot be worked out from the total count.
+func pageEndpoints(next string, totalCount string, perPage int) []string {
+	total, err := strconv.Atoi(totalCount)
+	if err != nil || perPage <= 0 {
+		return nil
+	}
+
+	page, ok := pageParameter(next)
+	if !ok || page < 2 {
+		return nil
+	}
+
+	remaining := total - (page-1)*perPage
+	if remaining <= 0 {
+		return nil
+	}
+
+	count := (remaining + perPage - 1) / perPage
+	endpoints := make([]string, count)
+
+	for i := range endpoints {
+		endpoints[i] = setPageParameter(next, page+i)
+	}
+
+	return endpoints
+}
+
+func pageParameter(endpoint string) (int, bool) {
+	_, query, found := strings.Cut(endpoint, "?")
+	if !found {
+		return 0, false
+	}
+
+	for _, param := range strings.Split(query, "&") {
+		if value, ok := strings.CutPrefix(param, "page="); ok {
+			page, err := strconv.Atoi(value)
+			return page, err == nil
+		}
+	}
+
+	return 0, false
+}
+
+// setPageParameter replaces the page query parameter of endpoint, leaving
+// the other parameters exactly as the API sent them.
+func setPageParameter(endpoint string, page int) string {
+	path, query, _ := strings.Cut(endpoint, "?")
+	params := strings.Split(query, "&")
+
+	for i, param := range params {
+		if strings.HasPrefix(param, "page=") {
+			params[i] = "page=" + strconv.Itoa(page)
+		}
+	}
+
+	return path + "?" + strings.Join(params, "&")
+}
@@ -0,0 +1,161 @@
+package invoiced
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Invoiced/invoiced-go/v2/invdmockserver"
+)
+
+// newNumberedPageServer serves total customers, perPage at a time, by page
+// number and records the highest number of requests in flight.
+func newNumberedPageServer(total, perPage int, maxInFlight *int32) *httptest.Server {
+	var inFlight int32
+	var mu sync.Mutex
+
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		mu.Lock()
+		if n > *maxInFlight {
+			*maxInFlight = n
+		}
+		mu.Unlock()
+
+		page, err := strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil {
+			page = 1
+		}
+
+		// let the other requests catch up
+		time.Sleep(10 * time.Millisecond)
+
+		customers := make(Customers, 0)
+		for id := (page-1)*perPage + 1; id <= page*perPage && id <= total; id++ {
+			customers = append(customers, &Customer{Id: int64(id)})
+		}
+
+		link := `</customers?filter[name]=x&page=` + strconv.Itoa(page) + `>; rel="self"`
+		if page*perPage < total {
+			link += `, </customers?filter[name]=x&page=` + strconv.Itoa(page+1) + `>; rel="next"`
+		}
+
+		w.Header().Set("Link", link)
+		w.Header().Set("X-Total-Count", strconv.Itoa(total))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(customers)
+	}))
+}
+
+func TestCollectAllConcurrentPages(t *testing.T) {
+	var maxInFlight int32
+	server := newNumberedPageServer(11, 2, &maxInFlight)
+	defer server.Close()
+
+	client := NewMockApi("whatever", server, WithConcurrentPages(3))
+
+	customers, err := CollectAll[Customers](context.Background(), client, "/customers?filter[name]=x")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(customers) != 11 {
+		t.Fatal("Expected 11 customers, got", len(customers))
+	}
+
+	for i, customer := range customers {
+		if customer.Id != int64(i+1) {
+			t.Fatal("Customers are out of order at", i, customer.Id)
+		}
+	}
+
+	if maxInFlight > 3 {
+		t.Fatal("Expected at most 3 requests in flight, got", maxInFlight)
+	}
+}
+
+func TestCollectAllConcurrentPagesWithoutCount(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(true,
+		invdmockserver.ScriptedResponse{
+			Status:  200,
+			Headers: map[string]string{"Link": `</customers?page=1>; rel="self", </customers?page=2>; rel="next"`},
+			Body:    Customers{{Id: 1}},
+		},
+		invdmockserver.ScriptedResponse{
+			Status:  200,
+			Headers: map[string]string{"Link": `</customers?page=2>; rel="self"`},
+			Body:    Customers{{Id: 2}},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	client := NewMockApi("whatever", server.Server, WithConcurrentPages(4))
+
+	customers, err := CollectAll[Customers](context.Background(), client, "/customers")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(customers) != 2 || customers[1].Id != 2 {
+		t.Fatal("Unexpected customers", customers)
+	}
+
+	if len(server.Requests()) != 2 || server.Requests()[1].Url != "/customers?page=2" {
+		t.Fatal("Expected the pages to be followed sequentially")
+	}
+}
+
+func TestCollectAllConcurrentPagesError(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(true,
+		invdmockserver.ScriptedResponse{
+			Status: 200,
+			Headers: map[string]string{
+				"Link":          `</customers?page=1>; rel="self", </customers?page=2>; rel="next"`,
+				"X-Total-Count": "5",
+			},
+			Body: Customers{{Id: 1}},
+		},
+		invdmockserver.ScriptedResponse{Status: 404, Body: NewAPIError("invalid_request", "not found", "")},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	client := NewMockApi("whatever", server.Server, WithConcurrentPages(2))
+
+	_, err = CollectAll[Customers](context.Background(), client, "/customers")
+	if !IsNotFound(err) {
+		t.Fatal("Expected a not found error, got", err)
+	}
+}
+
+func TestPageEndpoints(t *testing.T) {
+	endpoints := pageEndpoints("/invoices?filter[status]=paid&page=2&per_page=100", "250", 100)
+	if len(endpoints) != 2 {
+		t.Fatal("Expected 2 endpoints, got", endpoints)
+	}
+
+	if endpoints[0] != "/invoices?filter[status]=paid&page=2&per_page=100" || endpoints[1] != "/invoices?filter[status]=paid&page=3&per_page=100" {
+		t.Fatal("Unexpected endpoints", endpoints)
+	}
+
+	if pageEndpoints("/invoices?cursor=abc", "250", 100) != nil {
+		t.Fatal("Expected no endpoints without a page parameter")
+	}
+
+	if pageEndpoints("/invoices?page=2", "", 100) != nil {
+		t.Fatal("Expected no endpoints without a total count")
+	}
+}
@@ -0,0 +1,323 @@
+package invoiced
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Query composes the parameters of a list request: filters, metadata
+// filters, sort, expand, exclude, include, page size and date windows. Every
+// value is URL-encoded. The methods return the query so that they can be
+// chained:
+//
+//	query := invoiced.NewQuery().
+//		Filter("status", invoiced.InvoiceStatusPastDue).
+//		Metadata("region", "emea").
+//		Sort("due_date", invoiced.ASC).
+//		Sort("number", invoiced.DESC).
+//		Expand("customer").
+//		PerPage(100)
+//	invoices, err := client.Invoice.ListAllQuery(query)
+//
+// Unlike Sort, the sort columns keep the order in which they are added,
+// which is their priority.
+type Query struct {
+	params  []queryParam
+	sort    []queryParam
+	expand  []string
+	exclude []string
+	include []string
+	err     error
+}
+
+type queryParam struct {
+	key   string
+	value string
+}
+
+func NewQuery() *Query {
+	return new(Query)
+}
+
+// set adds a parameter, or replaces its value if it is already set.
+func set(params []queryParam, key, value string) []queryParam {
+	for i := range params {
+		if params[i].key == key {
+			params[i].value = value
+			return params
+		}
+	}
+
+	return append(params, queryParam{key: key, value: value})
+}
+
+func unset(params []queryParam, key string) []queryParam {
+	for i := range params {
+		if params[i].key == key {
+			return append(params[:i], params[i+1:]...)
+		}
+	}
+
+	return params
+}
+
+// formatQueryValue formats strings, including named string types such as
+// InvoiceStatus, booleans, numbers and times, which are sent as Unix
+// timestamps.
+func formatQueryValue(value interface{}) (string, error) {
+	if t, ok := value.(time.Time); ok {
+		return strconv.FormatInt(t.Unix(), 10), nil
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		if v.Bool() {
+			return "1", nil
+		}
+		return "0", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), nil
+	}
+
+	return "", fmt.Errorf("invoiced: unsupported query value %v of type %T", value, value)
+}
+
+// Set sets a parameter that has no method of its own. The value is
+// formatted like the values of Filter.
+func (q *Query) Set(name string, value interface{}) *Query {
+	s, err := formatQueryValue(value)
+	if err != nil {
+		if q.err == nil {
+			q.err = fmt.Errorf("%w for %s", err, name)
+		}
+		return q
+	}
+
+	q.params = set(q.params, name, s)
+
+	return q
+}
+
+// Filter only lists the objects whose field has the given value. The value
+// can be a string, a boolean, a number or a time.Time.
+func (q *Query) Filter(field string, value interface{}) *Query {
+	return q.Set("filter["+field+"]", value)
+}
+
+// Metadata only lists the objects with the given metadata value.
+func (q *Query) Metadata(key, value string) *Query {
+	return q.Set("metadata["+key+"]", value)
+}
+
+// Sort adds a sort column, after those already added. Sorting again by the
+// same column changes its order but not its priority.
+func (q *Query) Sort(column string, order SortOrder) *Query {
+	q.sort = set(q.sort, column, order.String())
+	return q
+}
+
+func (q *Query) Expand(fields ...string) *Query {
+	q.expand = append(q.expand, fields...)
+	return q
+}
+
+func (q *Query) Exclude(fields ...string) *Query {
+	q.exclude = append(q.exclude, fields...)
+	return q
+}
+
+func (q *Query) Include(fields ...string) *Query {
+	q.include = append(q.include, fields...)
+	return q
+}
+
+// PerPage sets the number of items of each page, up to 100.
+func (q *Query) PerPage(n int) *Query {
+	return q.Set("per_page", n)
+}
+
+// timeParam sets a date window parameter, or removes it for the zero time.
+func (q *Query) timeParam(name string, t time.Time) *Query {
+	if t.IsZero() {
+		q.params = unset(q.params, name)
+		return q
+	}
+
+	return q.Set(name, t)
+}
+
+// StartDate only lists the objects dated on or after t.
+func (q *Query) StartDate(t time.Time) *Query {
+	return q.timeParam("start_date", t)
+}
+
+// EndDate only lists the objects dated on or before t.
+func (q *Query) EndDate(t time.Time) *Query {
+	return q.timeParam("end_date", t)
+}
+
+// DateRange combines StartDate and EndDate, e.g. with the bounds returned by
+// DayRange.
+func (q *Query) DateRange(start, end time.Time) *Query {
+	return q.StartDate(start).EndDate(end)
+}
+
+// UpdatedAfter only lists the objects updated after t.
+func (q *Query) UpdatedAfter(t time.Time) *Query {
+	return q.timeParam("updated_after", t)
+}
+
+// UpdatedBefore only lists the objects updated before t.
+func (q *Query) UpdatedBefore(t time.Time) *Query {
+	return q.timeParam("updated_before", t)
+}
+
+// WithFilter adds the values of a Filter, or of a metadata filter made with
+// NewMetadataFilter.
+func (q *Query) WithFilter(filter *Filter) *Query {
+	if filter == nil {
+		return q
+	}
+
+	prefix := "filter["
+	if filter.metadata {
+		prefix = "metadata["
+	}
+
+	keys := make([]string, 0, len(filter.params))
+	for key := range filter.params {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		q.params = set(q.params, prefix+key+"]", filter.params[key])
+	}
+
+	return q
+}
+
+// WithSort adds the columns of a Sort, in the alphabetical order of Sort.
+func (q *Query) WithSort(s *Sort) *Query {
+	if s == nil {
+		return q
+	}
+
+	columns := make([]string, 0, len(s.orders))
+	for column := range s.orders {
+		columns = append(columns, column)
+	}
+
+	sort.Strings(columns)
+
+	for _, column := range columns {
+		q.Sort(column, s.orders[column])
+	}
+
+	return q
+}
+
+func (q *Query) WithExpand(expand *Expand) *Query {
+	if expand == nil {
+		return q
+	}
+
+	return q.Expand(expand.params...)
+}
+
+func (q *Query) WithExclude(exclude *Exclude) *Query {
+	if exclude == nil {
+		return q
+	}
+
+	return q.Exclude(exclude.params...)
+}
+
+// Err returns the first error met while building the query, such as a
+// filter value of an unsupported type. The list methods return it instead
+// of sending the request.
+func (q *Query) Err() error {
+	if q == nil {
+		return nil
+	}
+
+	return q.err
+}
+
+// String returns the URL-encoded query, without the leading "?".
+func (q *Query) String() string {
+	if q == nil {
+		return ""
+	}
+
+	var b strings.Builder
+
+	add := func(key, value string) {
+		if b.Len() > 0 {
+			b.WriteByte('&')
+		}
+
+		b.WriteString(url.QueryEscape(key))
+		b.WriteByte('=')
+		b.WriteString(url.QueryEscape(value))
+	}
+
+	for _, p := range q.params {
+		add(p.key, p.value)
+	}
+
+	if len(q.sort) > 0 {
+		columns := make([]string, len(q.sort))
+		for i, p := range q.sort {
+			columns[i] = p.key + " " + p.value
+		}
+
+		add("sort", strings.Join(columns, ","))
+	}
+
+	if len(q.expand) > 0 {
+		add("expand", strings.Join(q.expand, ","))
+	}
+
+	if len(q.exclude) > 0 {
+		add("exclude", strings.Join(q.exclude, ","))
+	}
+
+	if len(q.include) > 0 {
+		add("include", strings.Join(q.include, ","))
+	}
+
+	return b.String()
+}
+
+// Endpoint appends the query to an endpoint, which may already have query
+// parameters of its own. A nil query leaves the endpoint as it is.
+func (q *Query) Endpoint(endpoint string) (string, error) {
+	if err := q.Err(); err != nil {
+		return "", err
+	}
+
+	encoded := q.String()
+	if encoded == "" {
+		return endpoint, nil
+	}
+
+	if strings.Contains(endpoint, "?") {
+		return endpoint + "&" + encoded, nil
+	}
+
+	return endpoint + "?" + encoded, nil
+}
@@ -0,0 +1,107 @@
+package invoiced
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Invoiced/invoiced-go/v2/invdmockserver"
+)
+
+func TestQuery(t *testing.T) {
+	query := NewQuery().
+		Filter("status", InvoiceStatusPastDue).
+		Filter("customer", int64(1234)).
+		Filter("paid", false).
+		Metadata("account rep", "Ann & Bob").
+		Sort("due_date", ASC).
+		Sort("number", DESC).
+		Sort("due_date", DESC).
+		Expand("customer", "items.catalog_item").
+		Exclude("items").
+		Include("ship_to").
+		PerPage(100).
+		DateRange(time.Unix(1704067200, 0), time.Unix(1706745599, 0))
+
+	expected := "filter%5Bstatus%5D=past_due&filter%5Bcustomer%5D=1234&filter%5Bpaid%5D=0" +
+		"&metadata%5Baccount+rep%5D=Ann+%26+Bob&per_page=100&start_date=1704067200&end_date=1706745599" +
+		"&sort=due_date+DESC%2Cnumber+DESC&expand=customer%2Citems.catalog_item&exclude=items&include=ship_to"
+
+	if query.String() != expected {
+		t.Fatal("Unexpected query", query.String())
+	}
+
+	query.EndDate(time.Time{})
+	if endpoint, err := query.Endpoint("/invoices?page=2"); err != nil || endpoint != "/invoices?page=2&"+
+		"filter%5Bstatus%5D=past_due&filter%5Bcustomer%5D=1234&filter%5Bpaid%5D=0&metadata%5Baccount+rep%5D=Ann+%26+Bob"+
+		"&per_page=100&start_date=1704067200&sort=due_date+DESC%2Cnumber+DESC&expand=customer%2Citems.catalog_item&exclude=items&include=ship_to" {
+		t.Fatal("Unexpected endpoint", endpoint, err)
+	}
+
+	var nilQuery *Query
+	if endpoint, err := nilQuery.Endpoint("/invoices"); err != nil || endpoint != "/invoices" {
+		t.Fatal("Unexpected endpoint", endpoint, err)
+	}
+}
+
+func TestQueryWithFilterAndSort(t *testing.T) {
+	filter := NewFilter()
+	_ = filter.Set("name", "Acme")
+	metadata := NewMetadataFilter()
+	_ = metadata.Set("region", "emea")
+	sort := NewSort()
+	sort.Set("name", DESC)
+
+	query := NewQuery().WithFilter(filter).WithFilter(metadata).WithSort(sort).Sort("id", ASC)
+
+	if query.String() != "filter%5Bname%5D=Acme&metadata%5Bregion%5D=emea&sort=name+DESC%2Cid+ASC" {
+		t.Fatal("Unexpected query", query.String())
+	}
+}
+
+func TestQueryError(t *testing.T) {
+	query := NewQuery().Filter("customer", []int{1, 2})
+
+	if query.Err() == nil {
+		t.Fatal("Expected an error")
+	}
+
+	server, err := invdmockserver.NewScriptedServer(true, invdmockserver.ScriptedResponse{Status: 200, Body: Coupons{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	coupons := NewResource[Coupon, CouponRequest, string](NewMockApi("whatever", server.Server), "coupon", "/coupons")
+
+	if _, err := coupons.ListAllQuery(query); !errors.Is(err, query.Err()) {
+		t.Fatal("Expected the query error, got", err)
+	}
+
+	if it := coupons.IterateQuery(query); it.Next() || it.Err() == nil {
+		t.Fatal("Expected the iteration to fail")
+	}
+
+	if len(server.Requests()) != 0 {
+		t.Fatal("Expected no request to be sent")
+	}
+}
+
+func TestResourceListAllQuery(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(true, invdmockserver.ScriptedResponse{Status: 200, Body: Coupons{{Id: "WELCOME"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	coupons := NewResource[Coupon, CouponRequest, string](NewMockApi("whatever", server.Server), "coupon", "/coupons")
+
+	list, err := coupons.ListAllQuery(NewQuery().Filter("currency", "usd").Sort("name", ASC))
+	if err != nil || len(list) != 1 {
+		t.Fatal("Unexpected list", list, err)
+	}
+
+	if url := server.Requests()[0].Url; url != "/coupons?filter%5Bcurrency%5D=usd&sort=name+ASC" {
+		t.Fatal("Unexpected request", url)
+	}
+}
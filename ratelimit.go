@@ -0,0 +1,186 @@
+package invoiced
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrRateLimitExceeded is returned instead of waiting when a fail-fast rate
+// limiter has no capacity left. It matches ErrRateLimited with errors.Is.
+var ErrRateLimitExceeded = fmt.Errorf("%w: client-side limit exceeded", ErrRateLimited)
+
+// RateLimit configures a RateLimiter.
+type RateLimit struct {
+	// RequestsPerSecond is the sustained rate of requests.
+	RequestsPerSecond float64
+	// Burst is the number of requests that can be sent at once after a
+	// quiet period. It defaults to 1.
+	Burst int
+	// FailFast returns ErrRateLimitExceeded instead of waiting for capacity.
+	FailFast bool
+	// Adaptive pauses requests when the API reports that the limit is
+	// exhausted, through X-RateLimit-Remaining and X-RateLimit-Reset or
+	// through a 429 response with Retry-After.
+	Adaptive bool
+}
+
+// RateLimiter is a token bucket limiting the requests sent by one or more
+// Api instances. Every resource client of an api.Client shares the limiter
+// of its Api.
+type RateLimiter struct {
+	mu          sync.Mutex
+	limit       RateLimit
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+	now         func() time.Time
+}
+
+func NewRateLimiter(limit RateLimit) *RateLimiter {
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+
+	return &RateLimiter{
+		limit:  limit,
+		tokens: float64(limit.Burst),
+		now:    time.Now,
+	}
+}
+
+// WithRateLimit limits the requests sent by the Api.
+func WithRateLimit(limit RateLimit) Option {
+	return func(o *options) {
+		o.rateLimiter = NewRateLimiter(limit)
+	}
+}
+
+// WithRateLimiter makes the Api use a limiter that can be shared with other
+// Api instances, e.g. several clients using the same API key.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(o *options) {
+		o.rateLimiter = limiter
+	}
+}
+
+// SetRateLimiter is the setter form of WithRateLimiter. A nil limiter
+// removes the limit.
+func (c *Api) SetRateLimiter(limiter *RateLimiter) {
+	c.rateLimiter = limiter
+}
+
+// Wait blocks until a request may be sent, ctx is done, or, for a fail-fast
+// limiter, returns ErrRateLimitExceeded right away.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+
+	now := l.now()
+	wait := time.Duration(0)
+
+	if now.Before(l.pausedUntil) {
+		wait = l.pausedUntil.Sub(now)
+	}
+
+	if l.limit.RequestsPerSecond > 0 {
+		l.refill(now)
+
+		if l.tokens < 1 {
+			refillWait := time.Duration((1 - l.tokens) / l.limit.RequestsPerSecond * float64(time.Second))
+			if refillWait > wait {
+				wait = refillWait
+			}
+		}
+	}
+
+	if wait > 0 && l.limit.FailFast {
+		l.mu.Unlock()
+		return ErrRateLimitExceeded
+	}
+
+	// reserve the token now so that concurrent callers queue up behind us
+	if l.limit.RequestsPerSecond > 0 {
+		l.tokens--
+	}
+
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		if l.limit.RequestsPerSecond > 0 {
+			l.tokens++
+		}
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+func (l *RateLimiter) refill(now time.Time) {
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.limit.RequestsPerSecond
+		if l.tokens > float64(l.limit.Burst) {
+			l.tokens = float64(l.limit.Burst)
+		}
+	}
+
+	l.last = now
+}
+
+// Observe lets an adaptive limiter learn from the rate limit headers of a
+// response.
+func (l *RateLimiter) Observe(resp *http.Response) {
+	if !l.limit.Adaptive || resp == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	until := time.Time{}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			until = now.Add(wait)
+		}
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, ok := parseRateLimitReset(resp.Header.Get("X-RateLimit-Reset"), now); ok && reset.After(until) {
+			until = reset
+		}
+	}
+
+	if until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// parseRateLimitReset accepts either a unix timestamp or a number of seconds
+// from now.
+func parseRateLimitReset(s string, now time.Time) (time.Time, bool) {
+	seconds, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || seconds < 0 {
+		return time.Time{}, false
+	}
+
+	// anything before 2001-09-09 is taken as a delay
+	if seconds < 1000000000 {
+		return now.Add(time.Duration(seconds) * time.Second), true
+	}
+
+	return time.Unix(seconds, 0), true
+}
@@ -0,0 +1,173 @@
+package invoiced
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Invoiced/invoiced-go/v2/invdmockserver"
+)
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+func TestRateLimiterFailFast(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1600000000, 0)}
+	limiter := NewRateLimiter(RateLimit{RequestsPerSecond: 1, Burst: 2, FailFast: true})
+	limiter.now = clock.Now
+
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if err := limiter.Wait(ctx); err != nil {
+			t.Fatal("Burst request", i, "should pass", err)
+		}
+	}
+
+	err := limiter.Wait(ctx)
+	if !errors.Is(err, ErrRateLimitExceeded) || !IsRateLimited(err) {
+		t.Fatal("Expected ErrRateLimitExceeded, got", err)
+	}
+
+	clock.Advance(time.Second)
+
+	if err = limiter.Wait(ctx); err != nil {
+		t.Fatal("A token should have been refilled", err)
+	}
+
+	clock.Advance(time.Hour)
+
+	for i := 0; i < 2; i++ {
+		if err := limiter.Wait(ctx); err != nil {
+			t.Fatal("Tokens should refill up to the burst", err)
+		}
+	}
+
+	if err = limiter.Wait(ctx); err == nil {
+		t.Fatal("Tokens should not refill beyond the burst")
+	}
+}
+
+func TestRateLimiterBlocks(t *testing.T) {
+	limiter := NewRateLimiter(RateLimit{RequestsPerSecond: 50})
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Fatal("Requests were not spaced out", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	slow := NewRateLimiter(RateLimit{RequestsPerSecond: 0.001})
+	_ = slow.Wait(ctx)
+	if err := slow.Wait(ctx); err != context.Canceled {
+		t.Fatal("Expected context.Canceled, got", err)
+	}
+}
+
+func TestRateLimiterAdaptive(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1600000000, 0)}
+	limiter := NewRateLimiter(RateLimit{Burst: 1, FailFast: true, Adaptive: true})
+	limiter.now = clock.Now
+
+	limiter.Observe(&http.Response{StatusCode: 200, Header: http.Header{
+		"X-Ratelimit-Remaining": []string{"0"},
+		"X-Ratelimit-Reset":     []string{"1600000030"},
+	}})
+
+	if err := limiter.Wait(context.Background()); !errors.Is(err, ErrRateLimitExceeded) {
+		t.Fatal("Limiter should pause until the reset", err)
+	}
+
+	clock.Advance(30 * time.Second)
+
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatal("Limiter should resume after the reset", err)
+	}
+
+	limiter.Observe(&http.Response{StatusCode: 429, Header: http.Header{"Retry-After": []string{"5"}}})
+
+	if err := limiter.Wait(context.Background()); err == nil {
+		t.Fatal("Limiter should pause after a 429")
+	}
+
+	clock.Advance(5 * time.Second)
+
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatal("Limiter should resume after Retry-After", err)
+	}
+}
+
+func TestRateLimiterSharedByApi(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(true,
+		invdmockserver.ScriptedResponse{Status: 200, Body: &Customer{Id: 1234}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	limiter := NewRateLimiter(RateLimit{RequestsPerSecond: 0.001, FailFast: true})
+	first := NewMockApi("whatever", server.Server, WithRateLimiter(limiter), WithRetryPolicy(fastRetryPolicy()))
+	second := NewMockApi("whatever", server.Server)
+	second.SetRateLimiter(limiter)
+
+	_, err = first.Get("/customers/1234", new(Customer))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = second.Delete("/customers/1234")
+	if !errors.Is(err, ErrRateLimitExceeded) {
+		t.Fatal("Expected ErrRateLimitExceeded, got", err)
+	}
+
+	_, err = first.Get("/customers/1234", new(Customer))
+	if !errors.Is(err, ErrRateLimitExceeded) {
+		t.Fatal("Expected ErrRateLimitExceeded without retries, got", err)
+	}
+
+	if len(server.Requests()) != 1 {
+		t.Fatal("Only one request should have been sent, got", len(server.Requests()))
+	}
+}
+
+func TestParseRateLimitReset(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+
+	if reset, ok := parseRateLimitReset("60", now); !ok || !reset.Equal(now.Add(time.Minute)) {
+		t.Fatal("Unexpected reset for a delay", reset, ok)
+	}
+
+	if reset, ok := parseRateLimitReset("1600000060", now); !ok || !reset.Equal(now.Add(time.Minute)) {
+		t.Fatal("Unexpected reset for a timestamp", reset, ok)
+	}
+
+	if _, ok := parseRateLimitReset("tomorrow", now); ok {
+		t.Fatal("Unparseable reset should be ignored")
+	}
+}
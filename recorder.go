@@ -0,0 +1,173 @@
+package invoiced
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RecordedSpan is a span captured by a MemoryRecorder.
+type RecordedSpan struct {
+	Name       string
+	Parent     string
+	Attributes map[string]interface{}
+	Err        error
+	Ended      bool
+	Duration   time.Duration
+}
+
+type recordedMetric struct {
+	name       string
+	attributes map[string]interface{}
+	count      int64
+	latency    time.Duration
+}
+
+// MemoryRecorder is an Instrumentation that keeps everything in memory. It is
+// meant for tests that check which operations a piece of code performs.
+type MemoryRecorder struct {
+	mu        sync.Mutex
+	spans     []*RecordedSpan
+	counters  []*recordedMetric
+	latencies []*recordedMetric
+}
+
+func NewMemoryRecorder() *MemoryRecorder {
+	return new(MemoryRecorder)
+}
+
+type recorderSpanContextKey struct{}
+
+type recorderSpan struct {
+	recorder *MemoryRecorder
+	span     *RecordedSpan
+	start    time.Time
+}
+
+func (r *MemoryRecorder) StartSpan(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	span := &RecordedSpan{Name: name, Attributes: attributeMap(attrs)}
+
+	if parent, ok := ctx.Value(recorderSpanContextKey{}).(*RecordedSpan); ok {
+		span.Parent = parent.Name
+	}
+
+	r.mu.Lock()
+	r.spans = append(r.spans, span)
+	r.mu.Unlock()
+
+	return context.WithValue(ctx, recorderSpanContextKey{}, span), &recorderSpan{recorder: r, span: span, start: time.Now()}
+}
+
+func (s *recorderSpan) SetAttributes(attrs ...Attribute) {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+
+	for _, attr := range attrs {
+		s.span.Attributes[attr.Key] = attr.Value
+	}
+}
+
+func (s *recorderSpan) End(err error) {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+
+	s.span.Err = err
+	s.span.Ended = true
+	s.span.Duration = time.Since(s.start)
+}
+
+func (r *MemoryRecorder) AddCounter(ctx context.Context, name string, delta int64, attrs ...Attribute) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.counters = append(r.counters, &recordedMetric{name: name, attributes: attributeMap(attrs), count: delta})
+}
+
+func (r *MemoryRecorder) RecordLatency(ctx context.Context, name string, latency time.Duration, attrs ...Attribute) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.latencies = append(r.latencies, &recordedMetric{name: name, attributes: attributeMap(attrs), latency: latency})
+}
+
+// Spans returns a copy of the spans started so far, in order.
+func (r *MemoryRecorder) Spans() []RecordedSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	spans := make([]RecordedSpan, len(r.spans))
+	for i, span := range r.spans {
+		spans[i] = *span
+		spans[i].Attributes = make(map[string]interface{})
+		for key, value := range span.Attributes {
+			spans[i].Attributes[key] = value
+		}
+	}
+
+	return spans
+}
+
+// Counter sums the named counter over every recording that carries all of
+// the given attributes.
+func (r *MemoryRecorder) Counter(name string, attrs ...Attribute) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	total := int64(0)
+	for _, metric := range r.counters {
+		if metric.matches(name, attrs) {
+			total += metric.count
+		}
+	}
+
+	return total
+}
+
+// Latencies returns the durations recorded in the named histogram by every
+// recording that carries all of the given attributes.
+func (r *MemoryRecorder) Latencies(name string, attrs ...Attribute) []time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	latencies := make([]time.Duration, 0)
+	for _, metric := range r.latencies {
+		if metric.matches(name, attrs) {
+			latencies = append(latencies, metric.latency)
+		}
+	}
+
+	return latencies
+}
+
+// Reset forgets everything recorded so far.
+func (r *MemoryRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.spans = nil
+	r.counters = nil
+	r.latencies = nil
+}
+
+func (m *recordedMetric) matches(name string, attrs []Attribute) bool {
+	if m.name != name {
+		return false
+	}
+
+	for _, attr := range attrs {
+		if value, ok := m.attributes[attr.Key]; !ok || value != attr.Value {
+			return false
+		}
+	}
+
+	return true
+}
+
+func attributeMap(attrs []Attribute) map[string]interface{} {
+	m := make(map[string]interface{}, len(attrs))
+	for _, attr := range attrs {
+		m[attr.Key] = attr.Value
+	}
+
+	return m
+}
@@ -0,0 +1,202 @@
+package invoiced
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// ID is the type of a resource identifier. Most resources have a numeric
+// id, while the ones with a user chosen id, such as coupons, items, plans
+// and tax rates, have a string id.
+type ID interface {
+	~int64 | ~string
+}
+
+// Resource implements the operations shared by the resources of the API,
+// where T is the resource, Req the request used to create or update it and
+// I the type of its id. The resource clients are built on it:
+//
+//	coupons := invoiced.Resource[invoiced.Coupon, invoiced.CouponRequest, string]{
+//		Api:  client.Api,
+//		Name: "coupon",
+//		Path: "/coupons",
+//	}
+//	coupon, err := coupons.Retrieve("WELCOME")
+//
+// Name is used to name the operations reported to the Instrumentation, e.g.
+// "coupon.retrieve".
+type Resource[T any, Req any, I ID] struct {
+	Api  *Api
+	Name string
+	Path string
+}
+
+// NewResource returns the Resource for the endpoints found under path.
+func NewResource[T any, Req any, I ID](api *Api, name string, path string) Resource[T, Req, I] {
+	return Resource[T, Req, I]{Api: api, Name: name, Path: path}
+}
+
+// Endpoint returns the endpoint of the resource with the given id. The id is
+// escaped, since user chosen ids may contain e.g. a slash.
+func (r Resource[T, Req, I]) Endpoint(id I) string {
+	return r.Path + "/" + url.PathEscape(fmt.Sprint(id))
+}
+
+func (r Resource[T, Req, I]) Create(request *Req) (*T, error) {
+	return r.CreateCtx(context.Background(), request)
+}
+
+func (r Resource[T, Req, I]) CreateCtx(ctx context.Context, request *Req) (_ *T, err error) {
+	ctx, op := r.Api.StartOperation(ctx, r.Name+".create")
+	defer func() { op.End(err) }()
+
+	resp := new(T)
+	err = r.Api.CreateCtx(ctx, r.Path, request, resp)
+	return resp, err
+}
+
+func (r Resource[T, Req, I]) Retrieve(id I) (*T, error) {
+	return r.RetrieveCtx(context.Background(), id)
+}
+
+func (r Resource[T, Req, I]) RetrieveCtx(ctx context.Context, id I) (_ *T, err error) {
+	ctx, op := r.Api.StartOperation(ctx, r.Name+".retrieve")
+	defer func() { op.End(err) }()
+
+	resp := new(T)
+	_, err = r.Api.GetCtx(ctx, r.Endpoint(id), resp)
+	return resp, err
+}
+
+func (r Resource[T, Req, I]) Update(id I, request *Req) (*T, error) {
+	return r.UpdateCtx(context.Background(), id, request)
+}
+
+func (r Resource[T, Req, I]) UpdateCtx(ctx context.Context, id I, request *Req) (_ *T, err error) {
+	ctx, op := r.Api.StartOperation(ctx, r.Name+".update")
+	defer func() { op.End(err) }()
+
+	resp := new(T)
+	err = r.Api.UpdateCtx(ctx, r.Endpoint(id), request, resp)
+	return resp, err
+}
+
+func (r Resource[T, Req, I]) Delete(id I) error {
+	return r.DeleteCtx(context.Background(), id)
+}
+
+func (r Resource[T, Req, I]) DeleteCtx(ctx context.Context, id I) (err error) {
+	ctx, op := r.Api.StartOperation(ctx, r.Name+".delete")
+	defer func() { op.End(err) }()
+
+	return r.Api.DeleteCtx(ctx, r.Endpoint(id))
+}
+
+// List returns a single page along with the endpoint of the next page, which
+// is empty on the last page.
+func (r Resource[T, Req, I]) List(filter *Filter, sort *Sort) ([]*T, string, error) {
+	return r.ListCtx(context.Background(), filter, sort)
+}
+
+func (r Resource[T, Req, I]) ListCtx(ctx context.Context, filter *Filter, sort *Sort) (_ []*T, _ string, err error) {
+	ctx, op := r.Api.StartOperation(ctx, r.Name+".list")
+	defer func() { op.End(err) }()
+
+	items := make([]*T, 0)
+
+	nextEndpoint, err := r.Api.GetCtx(ctx, AddFilterAndSort(r.Path, filter, sort), &items)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return items, nextEndpoint, nil
+}
+
+// ListAll returns the items of every page.
+func (r Resource[T, Req, I]) ListAll(filter *Filter, sort *Sort) ([]*T, error) {
+	return r.ListAllCtx(context.Background(), filter, sort)
+}
+
+func (r Resource[T, Req, I]) ListAllCtx(ctx context.Context, filter *Filter, sort *Sort) (_ []*T, err error) {
+	ctx, op := r.Api.StartOperation(ctx, r.Name+".list_all")
+	defer func() { op.End(err) }()
+
+	return CollectAll[[]*T](ctx, r.Api, AddFilterAndSort(r.Path, filter, sort))
+}
+
+// Iterate walks through every page without holding more than one in memory.
+func (r Resource[T, Req, I]) Iterate(filter *Filter, sort *Sort) *Iter[*T] {
+	return r.IterateCtx(context.Background(), filter, sort)
+}
+
+func (r Resource[T, Req, I]) IterateCtx(ctx context.Context, filter *Filter, sort *Sort) *Iter[*T] {
+	return NewIter[*T](ctx, r.Api, AddFilterAndSort(r.Path, filter, sort))
+}
+
+// ListQuery is like List, with the parameters of a Query.
+func (r Resource[T, Req, I]) ListQuery(query *Query) ([]*T, string, error) {
+	return r.ListQueryCtx(context.Background(), query)
+}
+
+func (r Resource[T, Req, I]) ListQueryCtx(ctx context.Context, query *Query) (_ []*T, _ string, err error) {
+	ctx, op := r.Api.StartOperation(ctx, r.Name+".list")
+	defer func() { op.End(err) }()
+
+	endpoint, err := query.Endpoint(r.Path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	items := make([]*T, 0)
+
+	nextEndpoint, err := r.Api.GetCtx(ctx, endpoint, &items)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return items, nextEndpoint, nil
+}
+
+// ListAllQuery is like ListAll, with the parameters of a Query.
+func (r Resource[T, Req, I]) ListAllQuery(query *Query) ([]*T, error) {
+	return r.ListAllQueryCtx(context.Background(), query)
+}
+
+func (r Resource[T, Req, I]) ListAllQueryCtx(ctx context.Context, query *Query) (_ []*T, err error) {
+	ctx, op := r.Api.StartOperation(ctx, r.Name+".list_all")
+	defer func() { op.End(err) }()
+
+	endpoint, err := query.Endpoint(r.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return CollectAll[[]*T](ctx, r.Api, endpoint)
+}
+
+// IterateQuery is like Iterate, with the parameters of a Query.
+func (r Resource[T, Req, I]) IterateQuery(query *Query) *Iter[*T] {
+	return r.IterateQueryCtx(context.Background(), query)
+}
+
+func (r Resource[T, Req, I]) IterateQueryCtx(ctx context.Context, query *Query) *Iter[*T] {
+	endpoint, err := query.Endpoint(r.Path)
+	if err != nil {
+		return &Iter[*T]{ctx: ctx, api: r.Api, err: err}
+	}
+
+	return NewIter[*T](ctx, r.Api, endpoint)
+}
+
+// Count returns the total number of items.
+func (r Resource[T, Req, I]) Count() (int64, error) {
+	return r.CountCtx(context.Background())
+}
+
+func (r Resource[T, Req, I]) CountCtx(ctx context.Context) (_ int64, err error) {
+	ctx, op := r.Api.StartOperation(ctx, r.Name+".count")
+	defer func() { op.End(err) }()
+
+	return r.Api.CountCtx(ctx, r.Path)
+}
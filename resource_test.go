@@ -0,0 +1,104 @@
+package invoiced
+
+import (
+	"testing"
+
+	"github.com/Invoiced/invoiced-go/v2/invdmockserver"
+)
+
+func TestResourceRequests(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(true,
+		invdmockserver.ScriptedResponse{Status: 200, Body: &Coupon{Id: "WELCOME"}},
+		invdmockserver.ScriptedResponse{Status: 200, Body: &Coupon{Id: "WELCOME"}},
+		invdmockserver.ScriptedResponse{Status: 200, Body: &Coupon{Id: "WELCOME", Name: "Welcome"}},
+		invdmockserver.ScriptedResponse{Status: 204, Body: nil},
+		invdmockserver.ScriptedResponse{
+			Status:  200,
+			Headers: map[string]string{"Link": `</coupons?page=1>; rel="self", </coupons?page=2>; rel="next"`, "X-Total-Count": "2"},
+			Body:    Coupons{{Id: "WELCOME"}},
+		},
+		invdmockserver.ScriptedResponse{
+			Status:  200,
+			Headers: map[string]string{"X-Total-Count": "2"},
+			Body:    Coupons{},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	recorder := NewMemoryRecorder()
+	coupons := NewResource[Coupon, CouponRequest, string](NewMockApi("whatever", server.Server, WithInstrumentation(recorder)), "coupon", "/coupons")
+
+	if _, err := coupons.Create(&CouponRequest{Id: String("WELCOME")}); err != nil {
+		t.Fatal(err)
+	}
+
+	if coupon, err := coupons.Retrieve("WELCOME"); err != nil || coupon.Id != "WELCOME" {
+		t.Fatal("Unexpected coupon", coupon, err)
+	}
+
+	if coupon, err := coupons.Update("WELCOME", &CouponRequest{Name: String("Welcome")}); err != nil || coupon.Name != "Welcome" {
+		t.Fatal("Unexpected coupon", coupon, err)
+	}
+
+	if err := coupons.Delete("WELCOME"); err != nil {
+		t.Fatal(err)
+	}
+
+	list, next, err := coupons.List(nil, nil)
+	if err != nil || len(list) != 1 || next != "/coupons?page=2" {
+		t.Fatal("Unexpected page", list, next, err)
+	}
+
+	if count, err := coupons.Count(); err != nil || count != 2 {
+		t.Fatal("Unexpected count", count, err)
+	}
+
+	expected := []string{"POST /coupons", "GET /coupons/WELCOME", "PATCH /coupons/WELCOME", "DELETE /coupons/WELCOME", "GET /coupons", "GET /coupons"}
+	for i, req := range server.Requests() {
+		if req.Method+" "+req.Url != expected[i] {
+			t.Fatal("Unexpected request", i, req.Method, req.Url)
+		}
+	}
+
+	for _, op := range []string{"create", "retrieve", "update", "delete", "list", "count"} {
+		if n := recorder.Counter(MetricOperations, Attr("resource", "coupon"), Attr("operation", op)); n != 1 {
+			t.Fatal("Expected coupon."+op+" to be recorded once, got", n)
+		}
+	}
+}
+
+func TestResourceEndpoint(t *testing.T) {
+	invoices := NewResource[Invoice, InvoiceRequest, int64](nil, "invoice", "/invoices")
+	if invoices.Endpoint(1234) != "/invoices/1234" {
+		t.Fatal("Unexpected endpoint", invoices.Endpoint(1234))
+	}
+
+	plans := NewResource[Plan, PlanRequest, string](nil, "plan", "/plans")
+	if plans.Endpoint("starter") != "/plans/starter" {
+		t.Fatal("Unexpected endpoint", plans.Endpoint("starter"))
+	}
+
+	if plans.Endpoint("pro/annual?#1") != "/plans/pro%2Fannual%3F%231" {
+		t.Fatal("Unexpected endpoint", plans.Endpoint("pro/annual?#1"))
+	}
+}
+
+func TestResourceEscapedId(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(true, invdmockserver.ScriptedResponse{Status: 200, Body: Plan{Id: "pro/annual"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	plans := NewResource[Plan, PlanRequest, string](NewMockApi("whatever", server.Server), "plan", "/plans")
+	if _, err := plans.Retrieve("pro/annual"); err != nil {
+		t.Fatal(err)
+	}
+
+	if server.Requests()[0].Url != "/plans/pro%2Fannual" {
+		t.Fatal("Unexpected request", server.Requests()[0].Url)
+	}
+}
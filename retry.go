@@ -0,0 +1,188 @@
+package invoiced
+
+import (
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// RetryPolicy controls how failed requests are retried. Requests are retried
+// when the server answers 429 or 5xx, or when the connection fails, using
+// exponential backoff with jitter. A Retry-After header sent by the server
+// takes precedence over the computed backoff.
+//
+// Only idempotent requests are retried: GET, HEAD, OPTIONS, PUT and DELETE,
+// plus any request that carries an Idempotency-Key header.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+	// MaxElapsed bounds the total time spent on a request including waits.
+	// Zero means no bound.
+	MaxElapsed time.Duration
+	// InitialBackoff is the wait before the first retry. It doubles on each
+	// following retry up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// DefaultRetryPolicy returns the policy recommended for most integrations.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    4,
+		MaxElapsed:     time.Minute,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     15 * time.Second,
+	}
+}
+
+// SetRetryPolicy enables automatic retries. A nil policy disables them.
+func (c *Api) SetRetryPolicy(policy *RetryPolicy) {
+	c.retryPolicy = policy
+}
+
+func (p *RetryPolicy) canRetry(req *http.Request) bool {
+	if req.Header.Get(idempotencyKeyHeader) != "" {
+		return true
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, ErrRateLimitExceeded)
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	return resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented
+}
+
+// backoff returns how long to wait before the given retry (1 for the first
+// retry), honoring Retry-After when the server sent one.
+func (p *RetryPolicy) backoff(retry int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return wait
+		}
+	}
+
+	wait := p.InitialBackoff
+	for i := 1; i < retry && (p.MaxBackoff <= 0 || wait < p.MaxBackoff); i++ {
+		wait *= 2
+	}
+
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+
+	if wait <= 0 {
+		return 0
+	}
+
+	// equal jitter: half of the wait is fixed, the other half random
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(wait-half)+1))
+}
+
+func parseRetryAfter(s string) (time.Duration, bool) {
+	if s == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(s); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(s); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+func (c *Api) do(req *http.Request) (*http.Response, error) {
+	policy := c.retryPolicy
+	if policy == nil || policy.MaxAttempts <= 1 || !policy.canRetry(req) {
+		return c.send(req)
+	}
+
+	ctx := req.Context()
+	start := time.Now()
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(req)
+
+		if attempt >= policy.MaxAttempts || ctx.Err() != nil || !shouldRetry(resp, err) {
+			return resp, err
+		}
+
+		if req.Body != nil && req.GetBody == nil {
+			// the body cannot be replayed
+			return resp, err
+		}
+
+		wait := policy.backoff(attempt, resp)
+		if policy.MaxElapsed > 0 && time.Since(start)+wait > policy.MaxElapsed {
+			return resp, err
+		}
+
+		c.logRetry(ctx, req, attempt, wait, resp, err)
+
+		if resp != nil {
+			closeBody(resp)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		req = req.Clone(ctx)
+		if req.GetBody != nil {
+			req.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// send performs a single HTTP exchange within the rate limit.
+func (c *Api) send(req *http.Request) (*http.Response, error) {
+	if c.rateLimiter == nil {
+		return c.client.Do(req)
+	}
+
+	if err := c.rateLimiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err == nil {
+		c.rateLimiter.Observe(resp)
+	}
+
+	return resp, err
+}
@@ -0,0 +1,209 @@
+package invoiced
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Invoiced/invoiced-go/v2/invdmockserver"
+)
+
+func fastRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+	}
+}
+
+func TestRetryGetUntilSuccess(t *testing.T) {
+	unavailable := invdmockserver.ScriptedResponse{Status: 503, Body: NewAPIError("api_error", "try again", "")}
+	server, err := invdmockserver.NewScriptedServer(true,
+		unavailable,
+		invdmockserver.ScriptedResponse{Status: 429, Headers: map[string]string{"Retry-After": "0"}, Body: NewAPIError("rate_limit_error", "slow down", "")},
+		invdmockserver.ScriptedResponse{Status: 200, Body: &Customer{Id: 1234}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	client := NewMockApi("whatever", server.Server)
+	client.SetRetryPolicy(fastRetryPolicy())
+
+	customer := new(Customer)
+	_, err = client.Get("/customers/1234", customer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if customer.Id != 1234 {
+		t.Fatal("Unexpected customer", customer)
+	}
+
+	if len(server.Requests()) != 3 {
+		t.Fatal("Expected 3 attempts, got", len(server.Requests()))
+	}
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(true,
+		invdmockserver.ScriptedResponse{Status: 502, Body: NewAPIError("api_error", "bad gateway", "")},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	client := NewMockApi("whatever", server.Server)
+	client.SetRetryPolicy(fastRetryPolicy())
+
+	err = client.Delete("/customers/1234")
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	if len(server.Requests()) != 3 {
+		t.Fatal("Expected 3 attempts, got", len(server.Requests()))
+	}
+}
+
+func TestRetrySkipsPostWithoutIdempotencyKey(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(true,
+		invdmockserver.ScriptedResponse{Status: 500, Body: NewAPIError("api_error", "oops", "")},
+		invdmockserver.ScriptedResponse{Status: 200, Body: &Customer{Id: 1234}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	client := NewMockApi("whatever", server.Server)
+	client.SetRetryPolicy(fastRetryPolicy())
+
+	err = client.Create("/customers", &CustomerRequest{Name: String("Jane")}, new(Customer))
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	if len(server.Requests()) != 1 {
+		t.Fatal("Expected a single attempt, got", len(server.Requests()))
+	}
+}
+
+func TestRetryPostWithIdempotencyKey(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(true,
+		invdmockserver.ScriptedResponse{Status: 500, Body: NewAPIError("api_error", "oops", "")},
+		invdmockserver.ScriptedResponse{Status: 200, Body: &Customer{Id: 1234}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	client := NewMockApi("whatever", server.Server)
+	client.SetRetryPolicy(fastRetryPolicy())
+
+	req, err := http.NewRequest("POST", server.URL+"/customers", bytes.NewBufferString(`{"name":"Jane"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set(idempotencyKeyHeader, "abc123")
+
+	resp, err := client.do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		t.Fatal("Expected success, got", resp.StatusCode)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 2 {
+		t.Fatal("Expected 2 attempts, got", len(requests))
+	}
+
+	if requests[1].Body != `{"name":"Jane"}` {
+		t.Fatal("Body was not replayed on retry:", requests[1].Body)
+	}
+}
+
+func TestRetryStopsWhenContextDone(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(true,
+		invdmockserver.ScriptedResponse{Status: 503, Body: NewAPIError("api_error", "try again", "")},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	client := NewMockApi("whatever", server.Server)
+	client.SetRetryPolicy(&RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = client.GetCtx(ctx, "/customers/1234", new(Customer))
+	if err != context.DeadlineExceeded {
+		t.Fatal("Expected context.DeadlineExceeded, got", err)
+	}
+}
+
+func TestRetryRespectsMaxElapsed(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(true,
+		invdmockserver.ScriptedResponse{Status: 503, Headers: map[string]string{"Retry-After": "120"}, Body: NewAPIError("api_error", "try again", "")},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	client := NewMockApi("whatever", server.Server)
+	client.SetRetryPolicy(&RetryPolicy{MaxAttempts: 5, MaxElapsed: time.Second})
+
+	_, err = client.Get("/customers/1234", new(Customer))
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	if len(server.Requests()) != 1 {
+		t.Fatal("Expected a single attempt, got", len(server.Requests()))
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	wait, ok := parseRetryAfter("7")
+	if !ok || wait != 7*time.Second {
+		t.Fatal("Unexpected wait", wait, ok)
+	}
+
+	wait, ok = parseRetryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	if !ok || wait != 0 {
+		t.Fatal("Unexpected wait for a date in the past", wait, ok)
+	}
+
+	if _, ok = parseRetryAfter("soon"); ok {
+		t.Fatal("Unparseable Retry-After should be ignored")
+	}
+}
+
+func TestRetryBackoffIsBounded(t *testing.T) {
+	policy := &RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for retry := 1; retry < 10; retry++ {
+		wait := policy.backoff(retry, nil)
+		if wait > time.Second || wait < 50*time.Millisecond {
+			t.Fatal("Backoff out of bounds for retry", retry, wait)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"3"}}, Body: ioutil.NopCloser(bytes.NewReader(nil))}
+	if wait := policy.backoff(1, resp); wait != 3*time.Second {
+		t.Fatal("Retry-After should take precedence", wait)
+	}
+}
@@ -1,39 +1,78 @@
 package role
 
 import (
+	"context"
 	"github.com/Invoiced/invoiced-go/v2"
-	"strconv"
 )
 
 type Client struct {
 	*invoiced.Api
 }
 
+func (c *Client) resource() invoiced.Resource[invoiced.Role, struct{}, int64] {
+	return invoiced.NewResource[invoiced.Role, struct{}, int64](c.Api, "role", "/roles")
+}
+
 func (c *Client) Retrieve(id int64) (*invoiced.Role, error) {
-	resp := new(invoiced.Role)
-	_, err := c.Api.Get("/roles/"+strconv.FormatInt(id, 10), resp)
-	return resp, err
+	return c.RetrieveCtx(context.Background(), id)
+}
+
+func (c *Client) RetrieveCtx(ctx context.Context, id int64) (*invoiced.Role, error) {
+	return c.resource().RetrieveCtx(ctx, id)
 }
 
 func (c *Client) ListAll(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Roles, error) {
-	endpoint := invoiced.AddFilterAndSort("/roles", filter, sort)
+	return c.ListAllCtx(context.Background(), filter, sort)
+}
+
+func (c *Client) ListAllCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Roles, error) {
+	return c.resource().ListAllCtx(ctx, filter, sort)
+}
 
-	roles := make(invoiced.Roles, 0)
+func (c *Client) Iterate(filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.Role] {
+	return c.IterateCtx(context.Background(), filter, sort)
+}
 
-NEXT:
-	tmpRoles := make(invoiced.Roles, 0)
+func (c *Client) IterateCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.Role] {
+	return c.resource().IterateCtx(ctx, filter, sort)
+}
 
-	endpointTmp, err := c.Api.Get(endpoint, &tmpRoles)
+func (c *Client) List(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Roles, string, error) {
+	return c.ListCtx(context.Background(), filter, sort)
+}
 
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) ListCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Roles, string, error) {
+	return c.resource().ListCtx(ctx, filter, sort)
+}
 
-	roles = append(roles, tmpRoles...)
+func (c *Client) ListQuery(query *invoiced.Query) (invoiced.Roles, string, error) {
+	return c.ListQueryCtx(context.Background(), query)
+}
 
-	if endpointTmp != "" {
-		goto NEXT
-	}
+func (c *Client) ListQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.Roles, string, error) {
+	return c.resource().ListQueryCtx(ctx, query)
+}
+
+func (c *Client) ListAllQuery(query *invoiced.Query) (invoiced.Roles, error) {
+	return c.ListAllQueryCtx(context.Background(), query)
+}
+
+func (c *Client) ListAllQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.Roles, error) {
+	return c.resource().ListAllQueryCtx(ctx, query)
+}
+
+func (c *Client) IterateQuery(query *invoiced.Query) *invoiced.Iter[*invoiced.Role] {
+	return c.IterateQueryCtx(context.Background(), query)
+}
+
+func (c *Client) IterateQueryCtx(ctx context.Context, query *invoiced.Query) *invoiced.Iter[*invoiced.Role] {
+	return c.resource().IterateQueryCtx(ctx, query)
+}
+
+func (c *Client) Count() (int64, error) {
+	return c.CountCtx(context.Background())
+}
 
-	return roles, nil
+func (c *Client) CountCtx(ctx context.Context) (int64, error) {
+	return c.resource().CountCtx(ctx)
 }
@@ -1,6 +1,7 @@
 package subscription
 
 import (
+	"context"
 	"github.com/Invoiced/invoiced-go/v2"
 	"strconv"
 )
@@ -9,35 +10,58 @@ type Client struct {
 	*invoiced.Api
 }
 
+func (c *Client) resource() invoiced.Resource[invoiced.Subscription, invoiced.SubscriptionRequest, int64] {
+	return invoiced.NewResource[invoiced.Subscription, invoiced.SubscriptionRequest, int64](c.Api, "subscription", "/subscriptions")
+}
+
 func (c *Client) Create(request *invoiced.SubscriptionRequest) (*invoiced.Subscription, error) {
-	resp := new(invoiced.Subscription)
-	err := c.Api.Create("/subscriptions", request, resp)
-	return resp, err
+	return c.CreateCtx(context.Background(), request)
+}
+
+func (c *Client) CreateCtx(ctx context.Context, request *invoiced.SubscriptionRequest) (*invoiced.Subscription, error) {
+	return c.resource().CreateCtx(ctx, request)
 }
 
 func (c *Client) Retrieve(id int64) (*invoiced.Subscription, error) {
-	resp := new(invoiced.Subscription)
-	_, err := c.Api.Get("/subscriptions/"+strconv.FormatInt(id, 10), resp)
-	return resp, err
+	return c.RetrieveCtx(context.Background(), id)
+}
+
+func (c *Client) RetrieveCtx(ctx context.Context, id int64) (*invoiced.Subscription, error) {
+	return c.resource().RetrieveCtx(ctx, id)
 }
 
 func (c *Client) RetrievePlanCustomerExpanded(id int64) (*invoiced.Subscription, error) {
+	return c.RetrievePlanCustomerExpandedCtx(context.Background(), id)
+}
+
+func (c *Client) RetrievePlanCustomerExpandedCtx(ctx context.Context, id int64) (_ *invoiced.Subscription, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "subscription.retrieve_plan_customer_expanded")
+	defer func() { op.End(err) }()
+
 	resp := new(invoiced.Subscription)
-	_, err := c.Api.Get("/subscriptions/"+strconv.FormatInt(id, 10)+"?expand=plan,customer,addons.catalog_item,addons.plan", resp)
+	_, err = c.Api.GetCtx(ctx, "/subscriptions/"+strconv.FormatInt(id, 10)+"?expand=plan,customer,addons.catalog_item,addons.plan", resp)
 	return resp, err
 }
 
 func (c *Client) Update(id int64, request *invoiced.SubscriptionRequest) (*invoiced.Subscription, error) {
-	endpoint := "/subscriptions/" + strconv.FormatInt(id, 10)
-	resp := new(invoiced.Subscription)
-	err := c.Api.Update(endpoint, request, resp)
-	return resp, err
+	return c.UpdateCtx(context.Background(), id, request)
+}
+
+func (c *Client) UpdateCtx(ctx context.Context, id int64, request *invoiced.SubscriptionRequest) (*invoiced.Subscription, error) {
+	return c.resource().UpdateCtx(ctx, id, request)
 }
 
 func (c *Client) Cancel(id int64) error {
+	return c.CancelCtx(context.Background(), id)
+}
+
+func (c *Client) CancelCtx(ctx context.Context, id int64) (err error) {
+	ctx, op := c.Api.StartOperation(ctx, "subscription.cancel")
+	defer func() { op.End(err) }()
+
 	endpoint := "/subscriptions/" + strconv.FormatInt(id, 10)
 
-	err := c.Api.Delete(endpoint)
+	err = c.Api.DeleteCtx(ctx, endpoint)
 	if err != nil {
 		return err
 	}
@@ -46,10 +70,25 @@ func (c *Client) Cancel(id int64) error {
 }
 
 func (c *Client) Count() (int64, error) {
-	return c.Api.Count("/subscriptions")
+	return c.CountCtx(context.Background())
+}
+
+func (c *Client) CountCtx(ctx context.Context) (int64, error) {
+	return c.resource().CountCtx(ctx)
 }
 
 func (c *Client) ListAllQueryParameters(parameters map[string]string) (invoiced.Subscriptions, error) {
+	return c.ListAllQueryParametersCtx(context.Background(), parameters)
+}
+
+func (c *Client) ListAllQueryParametersCtx(ctx context.Context, parameters map[string]string) (_ invoiced.Subscriptions, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "subscription.list_all_query_parameters")
+	defer func() { op.End(err) }()
+
+	return invoiced.CollectAll[invoiced.Subscriptions](ctx, c.Api, queryParametersEndpoint(parameters))
+}
+
+func queryParametersEndpoint(parameters map[string]string) string {
 	endpoint := "/subscriptions"
 
 	if len(parameters) > 0 {
@@ -58,103 +97,98 @@ func (c *Client) ListAllQueryParameters(parameters map[string]string) (invoiced.
 		}
 	}
 
-	subscriptions := make(invoiced.Subscriptions, 0)
-
-NEXT:
-	tmpSubscriptions := make(invoiced.Subscriptions, 0)
-
-	endpoint, err := c.Api.Get(endpoint, &tmpSubscriptions)
-
-	if err != nil {
-		return nil, err
-	}
-
-	subscriptions = append(subscriptions, tmpSubscriptions...)
-
-	if endpoint != "" {
-		goto NEXT
-	}
-
-	return subscriptions, nil
-
+	return endpoint
 }
 
 func (c *Client) ListAllCanceled(canceled bool) (invoiced.Subscriptions, error) {
+	return c.ListAllCanceledCtx(context.Background(), canceled)
+}
+
+func (c *Client) ListAllCanceledCtx(ctx context.Context, canceled bool) (_ invoiced.Subscriptions, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "subscription.list_all_canceled")
+	defer func() { op.End(err) }()
+
 	parameters := make(map[string]string)
 
 	if canceled {
 		parameters["canceled"] = "1"
 	}
 
-	return c.ListAllQueryParameters(parameters)
+	return invoiced.CollectAll[invoiced.Subscriptions](ctx, c.Api, queryParametersEndpoint(parameters))
 }
 
 func (c *Client) ListAll(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Subscriptions, error) {
-	endpoint := "/subscriptions"
-	endpoint = invoiced.AddFilterAndSort(endpoint, filter, sort)
-
-	subscriptions := make(invoiced.Subscriptions, 0)
-
-NEXT:
-	tmpSubscriptions := make(invoiced.Subscriptions, 0)
-
-	endpoint, err := c.Api.Get(endpoint, &tmpSubscriptions)
-
-	if err != nil {
-		return nil, err
-	}
+	return c.ListAllCtx(context.Background(), filter, sort)
+}
 
-	subscriptions = append(subscriptions, tmpSubscriptions...)
+func (c *Client) ListAllCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Subscriptions, error) {
+	return c.resource().ListAllCtx(ctx, filter, sort)
+}
 
-	if endpoint != "" {
-		goto NEXT
-	}
+func (c *Client) Iterate(filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.Subscription] {
+	return c.IterateCtx(context.Background(), filter, sort)
+}
 
-	return subscriptions, nil
+func (c *Client) IterateCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.Subscription] {
+	return c.resource().IterateCtx(ctx, filter, sort)
 }
 
 func (c *Client) ListAllCustomerExpanded(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Subscriptions, error) {
+	return c.ListAllCustomerExpandedCtx(context.Background(), filter, sort)
+}
+
+func (c *Client) ListAllCustomerExpandedCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (_ invoiced.Subscriptions, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "subscription.list_all_customer_expanded")
+	defer func() { op.End(err) }()
+
 	endpoint := "/subscriptions"
 	endpoint = invoiced.AddFilterAndSort(endpoint, filter, sort)
 	endpoint = invoiced.AddQueryParameter(endpoint, "expand", "customer")
 
-	subscriptions := make(invoiced.Subscriptions, 0)
-
-NEXT:
-	tmpSubscriptions := make(invoiced.Subscriptions, 0)
-
-	endpoint, err := c.Api.Get(endpoint, &tmpSubscriptions)
-
-	if err != nil {
-		return nil, err
-	}
+	return invoiced.CollectAll[invoiced.Subscriptions](ctx, c.Api, endpoint)
+}
 
-	subscriptions = append(subscriptions, tmpSubscriptions...)
+func (c *Client) List(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Subscriptions, string, error) {
+	return c.ListCtx(context.Background(), filter, sort)
+}
 
-	if endpoint != "" {
-		goto NEXT
-	}
+func (c *Client) ListCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Subscriptions, string, error) {
+	return c.resource().ListCtx(ctx, filter, sort)
+}
 
-	return subscriptions, nil
+func (c *Client) ListQuery(query *invoiced.Query) (invoiced.Subscriptions, string, error) {
+	return c.ListQueryCtx(context.Background(), query)
 }
 
-func (c *Client) List(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.Subscriptions, string, error) {
-	endpoint := "/subscriptions"
-	endpoint = invoiced.AddFilterAndSort(endpoint, filter, sort)
+func (c *Client) ListQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.Subscriptions, string, error) {
+	return c.resource().ListQueryCtx(ctx, query)
+}
 
-	subscriptions := make(invoiced.Subscriptions, 0)
+func (c *Client) ListAllQuery(query *invoiced.Query) (invoiced.Subscriptions, error) {
+	return c.ListAllQueryCtx(context.Background(), query)
+}
 
-	nextEndpoint, err := c.Api.Get(endpoint, &subscriptions)
+func (c *Client) ListAllQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.Subscriptions, error) {
+	return c.resource().ListAllQueryCtx(ctx, query)
+}
 
-	if err != nil {
-		return nil, "", err
-	}
+func (c *Client) IterateQuery(query *invoiced.Query) *invoiced.Iter[*invoiced.Subscription] {
+	return c.IterateQueryCtx(context.Background(), query)
+}
 
-	return subscriptions, nextEndpoint, nil
+func (c *Client) IterateQueryCtx(ctx context.Context, query *invoiced.Query) *invoiced.Iter[*invoiced.Subscription] {
+	return c.resource().IterateQueryCtx(ctx, query)
 }
 
 func (c *Client) Preview(request *invoiced.SubscriptionPreviewRequest) (*invoiced.SubscriptionPreview, error) {
+	return c.PreviewCtx(context.Background(), request)
+}
+
+func (c *Client) PreviewCtx(ctx context.Context, request *invoiced.SubscriptionPreviewRequest) (_ *invoiced.SubscriptionPreview, err error) {
+	ctx, op := c.Api.StartOperation(ctx, "subscription.preview")
+	defer func() { op.End(err) }()
+
 	resp := new(invoiced.SubscriptionPreview)
-	err := c.Api.Create("/subscriptions/preview", request, resp)
+	err = c.Api.CreateCtx(ctx, "/subscriptions/preview", request, resp)
 	return resp, err
 }
@@ -15,8 +15,8 @@ func TestSubscriptionCreate(t *testing.T) {
 	mockSubscriptionResponse := new(invoiced.Subscription)
 	mockSubscriptionResponse.Id = mockSubscriptionResponseID
 	mockSubscriptionResponse.CreatedAt = time.Now().UnixNano()
-	mockSubscriptionResponse.Customer = 234112
-	mockSubscriptionResponse.Plan = "234"
+	mockSubscriptionResponse.Customer.Id = 234112
+	mockSubscriptionResponse.Plan.Id = "234"
 
 	server, err := invdmockserver.New(200, mockSubscriptionResponse, "json", true)
 	if err != nil {
@@ -70,8 +70,8 @@ func TestSubscriptionUpdate(t *testing.T) {
 	mockSubscriptionResponse := new(invoiced.Subscription)
 	mockSubscriptionResponse.Id = mockSubscriptionResponseID
 	mockSubscriptionResponse.CreatedAt = time.Now().UnixNano()
-	mockSubscriptionResponse.Customer = 234112
-	mockSubscriptionResponse.Plan = "234"
+	mockSubscriptionResponse.Customer.Id = 234112
+	mockSubscriptionResponse.Plan.Id = "234"
 	mockSubscriptionResponse.Cycles = 42
 
 	server, err := invdmockserver.New(200, mockSubscriptionResponse, "json", true)
@@ -194,8 +194,8 @@ func TestSubscriptionRetrieve(t *testing.T) {
 	mockSubscriptionResponseID := int64(1523)
 	mockSubscriptionResponse := new(invoiced.Subscription)
 	mockSubscriptionResponse.Id = mockSubscriptionResponseID
-	mockSubscriptionResponse.Customer = 234112
-	mockSubscriptionResponse.Plan = "234"
+	mockSubscriptionResponse.Customer.Id = 234112
+	mockSubscriptionResponse.Plan.Id = "234"
 
 	mockSubscriptionResponse.CreatedAt = time.Now().UnixNano()
 
@@ -9,9 +9,9 @@ type SubscriptionAddonRequest struct {
 }
 
 type SubscriptionAddon struct {
-	Amount    float64 `json:"amount"`
-	CreatedAt int64   `json:"created_at"`
-	Id        int64   `json:"id"`
-	Plan      string  `json:"plan"`
-	Quantity  float64 `json:"quantity"`
+	Amount    float64                  `json:"amount"`
+	CreatedAt int64                    `json:"created_at"`
+	Id        int64                    `json:"id"`
+	Plan      Expandable[Plan, string] `json:"plan"`
+	Quantity  float64                  `json:"quantity"`
 }
@@ -2,8 +2,8 @@ package invoiced
 
 import (
 	"encoding/json"
-	"strconv"
-	"strings"
+	"fmt"
+	"time"
 )
 
 type SubscriptionRequest struct {
@@ -32,43 +32,87 @@ type SubscriptionRequest struct {
 	Taxes                 []*TaxRequest               `json:"taxes,omitempty"`
 }
 
+func (r *SubscriptionRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+
+	var f fieldErrors
+	r.validate(&f)
+
+	return f.err()
+}
+
+// ValidateCreate also requires the customer and the plan.
+func (r *SubscriptionRequest) ValidateCreate() error {
+	if r == nil {
+		return nil
+	}
+
+	var f fieldErrors
+	f.required("customer", r.Customer != nil)
+	f.required("plan", r.Plan != nil && *r.Plan != "")
+	r.validate(&f)
+
+	return f.err()
+}
+
+func (r *SubscriptionRequest) validate(f *fieldErrors) {
+	f.id("customer", r.Customer)
+	f.nonNegative("quantity", r.Quantity)
+	f.nonNegative("amount", r.Amount)
+	f.nonNegativeInt("cycles", r.Cycles)
+	f.nonNegativeInt("bill_in_advance_days", r.BillInAdvanceDays)
+	f.oneOf("bill_in", r.BillIn, "advance", "arrears")
+	f.notBefore("contract_period_end", r.ContractPeriodEnd, "contract_period_start", r.ContractPeriodStart)
+
+	for i, addon := range r.Addons {
+		path := fmt.Sprintf("addons[%d]", i)
+		if addon == nil {
+			f.add(path, "must not be null")
+			continue
+		}
+
+		f.nonNegative(path+".quantity", addon.Quantity)
+		if addon.Id == nil {
+			f.required(path+".plan", addon.Plan != nil && *addon.Plan != "")
+		}
+	}
+}
+
 type Subscription struct {
-	Addons                []SubscriptionAddon    `json:"addons"`
-	Amount                float64                `json:"amount"`
-	BillIn                string                 `json:"bill_in"`
-	BillInAdvanceDays     int64                  `json:"bill_in_advance_days"`
-	CancelAtPeriodEnd     bool                   `json:"cancel_at_period_end"`
-	CanceledAt            int64                  `json:"cancel_at"`
-	ContractPeriodEnd     int64                  `json:"contract_period_end"`
-	ContractPeriodStart   int64                  `json:"contract_period_start"`
-	ContractRenewalCycles int64                  `json:"contract_renewal_cycles"`
-	ContractRenewalMode   string                 `json:"contract_renewal_mode"`
-	CreatedAt             int64                  `json:"created_at"`
-	Customer              int64                  `json:"-"`
-	CustomerFull          *Customer              `json:"-"`
-	CustomerRaw           json.RawMessage        `json:"customer"`
-	Cycles                int64                  `json:"cycles"`
-	Discounts             []Discount             `json:"discount"`
-	Id                    int64                  `json:"id"`
-	Metadata              map[string]interface{} `json:"metadata"`
-	Mrr                   float64                `json:"MRR"`
-	Object                string                 `json:"object"`
-	Paused                bool                   `json:"paused"`
-	PeriodEnd             int64                  `json:"period_end"`
-	PeriodStart           int64                  `json:"period_start"`
-	Plan                  string                 `json:"-"`
-	PlanFull              *Plan                  `json:"-"`
-	PlanRaw               json.RawMessage        `json:"plan"`
-	Prorate               bool                   `json:"prorate"`
-	Quantity              float64                `json:"quantity"`
-	RecurringTotal        float64                `json:"recurring_total"`
-	RenewsNext            int64                  `json:"renews_next"`
-	ShipTo                *ShippingDetail        `json:"ship_to"`
-	StartDate             int64                  `json:"start_date"`
-	Status                string                 `json:"status"`
-	Taxes                 []Tax                  `json:"taxes"`
-	UpdatedAt             int64                  `json:"updated_at"`
-	Url                   string                 `json:"url"`
+	Addons                []SubscriptionAddon         `json:"addons"`
+	Amount                float64                     `json:"amount"`
+	BillIn                string                      `json:"bill_in"`
+	BillInAdvanceDays     int64                       `json:"bill_in_advance_days"`
+	CancelAtPeriodEnd     bool                        `json:"cancel_at_period_end"`
+	CanceledAt            int64                       `json:"cancel_at"`
+	ContractPeriodEnd     int64                       `json:"contract_period_end"`
+	ContractPeriodStart   int64                       `json:"contract_period_start"`
+	ContractRenewalCycles int64                       `json:"contract_renewal_cycles"`
+	ContractRenewalMode   string                      `json:"contract_renewal_mode"`
+	CreatedAt             int64                       `json:"created_at"`
+	Customer              Expandable[Customer, int64] `json:"customer"`
+	Cycles                int64                       `json:"cycles"`
+	Discounts             []Discount                  `json:"discount"`
+	Id                    int64                       `json:"id"`
+	Metadata              map[string]interface{}      `json:"metadata"`
+	Mrr                   float64                     `json:"MRR"`
+	Object                string                      `json:"object"`
+	Paused                bool                        `json:"paused"`
+	PeriodEnd             int64                       `json:"period_end"`
+	PeriodStart           int64                       `json:"period_start"`
+	Plan                  Expandable[Plan, string]    `json:"plan"`
+	Prorate               bool                        `json:"prorate"`
+	Quantity              float64                     `json:"quantity"`
+	RecurringTotal        float64                     `json:"recurring_total"`
+	RenewsNext            int64                       `json:"renews_next"`
+	ShipTo                *ShippingDetail             `json:"ship_to"`
+	StartDate             int64                       `json:"start_date"`
+	Status                SubscriptionStatus          `json:"status"`
+	Taxes                 []Tax                       `json:"taxes"`
+	UpdatedAt             int64                       `json:"updated_at"`
+	Url                   string                      `json:"url"`
 }
 
 type Subscriptions []*Subscription
@@ -95,88 +139,67 @@ type SubscriptionPreview struct {
 }
 
 type SubscriptionPreviewInvoice struct {
-	AttemptCount       int64                  `json:"attempt_count"`
-	AutoPay            bool                   `json:"autopay"`
-	Balance            float64                `json:"balance"`
-	Closed             bool                   `json:"closed"`
-	CreatedAt          int64                  `json:"created_at"`
-	Currency           string                 `json:"currency"`
-	Customer           int64                  `json:"customer"`
-	Date               int64                  `json:"date"`
-	Discounts          []Discount             `json:"discounts"`
-	Draft              bool                   `json:"draft"`
-	DueDate            int64                  `json:"due_date"`
-	Items              []LineItemPreview      `json:"items"`
-	Metadata           map[string]interface{} `json:"metadata"`
-	Name               string                 `json:"name"`
-	NextPaymentAttempt int64                  `json:"next_payment_attempt"`
-	Notes              string                 `json:"notes"`
-	Number             string                 `json:"number"`
-	Paid               bool                   `json:"paid"`
-	PaymentTerms       string                 `json:"payment_terms"`
-	PaymentUrl         string                 `json:"payment_url"`
-	PdfUrl             string                 `json:"pdf_url"`
-	Status             string                 `json:"status"`
-	Subtotal           float64                `json:"subtotal"`
-	Taxes              []Tax                  `json:"taxes"`
-	Total              float64                `json:"total"`
-	UpdatedAt          int64                  `json:"updated_at"`
-	Url                string                 `json:"url"`
-}
-
-func (i *Subscription) UnmarshalJSON(data []byte) error {
-	type subscription2 Subscription
-
-	if err := json.Unmarshal(data, (*subscription2)(i)); err != nil {
-		return err
-	}
-
-	rj := i.CustomerRaw
-
-	i.Customer, _ = strconv.ParseInt(string(rj), 10, 64)
-	customer := new(Customer)
-
-	err := json.Unmarshal(rj, customer)
-
-	if err == nil {
-		i.CustomerFull = customer
-		i.Customer = customer.Id
-	}
-
-	aj := i.PlanRaw
-
-	i.Plan = string(aj)
-	i.Plan = strings.Trim(i.Plan, "\"")
-
-	plan := new(Plan)
+	AttemptCount       int64                       `json:"attempt_count"`
+	AutoPay            bool                        `json:"autopay"`
+	Balance            float64                     `json:"balance"`
+	Closed             bool                        `json:"closed"`
+	CreatedAt          int64                       `json:"created_at"`
+	Currency           string                      `json:"currency"`
+	Customer           Expandable[Customer, int64] `json:"customer"`
+	Date               int64                       `json:"date"`
+	Discounts          []Discount                  `json:"discounts"`
+	Draft              bool                        `json:"draft"`
+	DueDate            int64                       `json:"due_date"`
+	Items              []LineItemPreview           `json:"items"`
+	Metadata           map[string]interface{}      `json:"metadata"`
+	Name               string                      `json:"name"`
+	NextPaymentAttempt int64                       `json:"next_payment_attempt"`
+	Notes              string                      `json:"notes"`
+	Number             string                      `json:"number"`
+	Paid               bool                        `json:"paid"`
+	PaymentTerms       PaymentTerms                `json:"payment_terms"`
+	PaymentUrl         string                      `json:"payment_url"`
+	PdfUrl             string                      `json:"pdf_url"`
+	Status             InvoiceStatus               `json:"status"`
+	Subtotal           float64                     `json:"subtotal"`
+	Taxes              []Tax                       `json:"taxes"`
+	Total              float64                     `json:"total"`
+	UpdatedAt          int64                       `json:"updated_at"`
+	Url                string                      `json:"url"`
+}
 
-	err = json.Unmarshal(aj, plan)
+func (s *Subscription) CreatedAtTime() time.Time {
+	return Time(s.CreatedAt)
+}
 
-	if err == nil {
-		i.PlanFull = plan
-		i.Plan = plan.Id
-	}
+func (s *Subscription) StartDateTime() time.Time {
+	return Time(s.StartDate)
+}
 
-	return nil
+func (s *Subscription) PeriodStartTime() time.Time {
+	return Time(s.PeriodStart)
 }
 
-func (i *Subscription) MarshalJSON() ([]byte, error) {
-	type subscription2 Subscription
-	i2 := (*subscription2)(i)
+func (s *Subscription) PeriodEndTime() time.Time {
+	return Time(s.PeriodEnd)
+}
 
-	if i2.Customer > 0 {
-		i2.CustomerRaw = []byte(strconv.FormatInt(i2.Customer, 10))
-	}
+func (s *Subscription) RenewsNextTime() time.Time {
+	return Time(s.RenewsNext)
+}
 
-	if len(i2.Plan) > 0 {
-		b, err := json.Marshal(i2.Plan)
+func (s *Subscription) ContractPeriodStartTime() time.Time {
+	return Time(s.ContractPeriodStart)
+}
 
-		if err != nil {
-			return nil, err
-		}
+func (s *Subscription) ContractPeriodEndTime() time.Time {
+	return Time(s.ContractPeriodEnd)
+}
 
-		i2.PlanRaw = b
-	}
+func (s *Subscription) CanceledAtTime() time.Time {
+	return Time(s.CanceledAt)
+}
 
-	return json.Marshal(i2)
+func (s *Subscription) UpdatedAtTime() time.Time {
+	return Time(s.UpdatedAt)
 }
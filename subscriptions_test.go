@@ -58,12 +58,12 @@ func TestUnMarshalSubscriptionObject(t *testing.T) {
 		t.Fatal("Subscription has incorrect id")
 	}
 
-	if so.Customer != 15444 {
+	if so.Customer.Id != 15444 {
 		t.Fatal("Subscription has incorrect type")
 	}
 	fmt.Println("so.plan ", so.Plan)
-	if so.Plan != "starter" {
-		t.Fatal("Subscription has incorrect plan2 -> " + so.Plan)
+	if so.Plan.Id != "starter" {
+		t.Fatal("Subscription has incorrect plan2 -> " + so.Plan.Id)
 	}
 
 	if so.Amount != 10.99 {
@@ -94,7 +94,7 @@ func TestUnMarshalSubscriptionObject(t *testing.T) {
 		t.Fatal("Subscription Addon 0 has incorrect status")
 	}
 
-	if so.Addons[0].Plan != "ipad-license" {
+	if so.Addons[0].Plan.Id != "ipad-license" {
 		t.Fatal("Subscription Addon Client 0 has incorrect value")
 	}
 
@@ -312,7 +312,7 @@ func TestSubscriptionPlanUnmarshall(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if so.Plan != "model-z" {
+	if so.Plan.Id != "model-z" {
 		t.Fatal("Client id is incorrect")
 	}
 
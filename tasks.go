@@ -1,5 +1,7 @@
 package invoiced
 
+import "time"
+
 type TaskRequest struct {
 	Action   *string `json:"action,omitempty"`
 	Complete *bool   `json:"complete,omitempty"`
@@ -25,3 +27,19 @@ type Task struct {
 }
 
 type Tasks []*Task
+
+func (t *Task) CreatedAtTime() time.Time {
+	return Time(t.CreatedAt)
+}
+
+func (t *Task) DueDateTime() time.Time {
+	return Time(t.DueDate)
+}
+
+func (t *Task) CompletedDateTime() time.Time {
+	return Time(t.CompletedDate)
+}
+
+func (t *Task) UpdatedAtTime() time.Time {
+	return Time(t.UpdatedAt)
+}
@@ -1,6 +1,7 @@
 package taxrate
 
 import (
+	"context"
 	"github.com/Invoiced/invoiced-go/v2"
 )
 
@@ -8,46 +9,94 @@ type Client struct {
 	*invoiced.Api
 }
 
+func (c *Client) resource() invoiced.Resource[invoiced.TaxRate, invoiced.TaxRateRequest, string] {
+	return invoiced.NewResource[invoiced.TaxRate, invoiced.TaxRateRequest, string](c.Api, "tax_rate", "/tax_rates")
+}
+
 func (c *Client) Create(request *invoiced.TaxRateRequest) (*invoiced.TaxRate, error) {
-	resp := new(invoiced.TaxRate)
-	err := c.Api.Create("/tax_rates", request, resp)
-	return resp, err
+	return c.CreateCtx(context.Background(), request)
+}
+
+func (c *Client) CreateCtx(ctx context.Context, request *invoiced.TaxRateRequest) (*invoiced.TaxRate, error) {
+	return c.resource().CreateCtx(ctx, request)
 }
 
 func (c *Client) Retrieve(id string) (*invoiced.TaxRate, error) {
-	resp := new(invoiced.TaxRate)
-	_, err := c.Api.Get("/tax_rates/"+id, resp)
-	return resp, err
+	return c.RetrieveCtx(context.Background(), id)
+}
+
+func (c *Client) RetrieveCtx(ctx context.Context, id string) (*invoiced.TaxRate, error) {
+	return c.resource().RetrieveCtx(ctx, id)
 }
 
 func (c *Client) Update(id string, request *invoiced.TaxRateRequest) (*invoiced.TaxRate, error) {
-	resp := new(invoiced.TaxRate)
-	err := c.Api.Update("/tax_rates/"+id, request, resp)
-	return resp, err
+	return c.UpdateCtx(context.Background(), id, request)
+}
+
+func (c *Client) UpdateCtx(ctx context.Context, id string, request *invoiced.TaxRateRequest) (*invoiced.TaxRate, error) {
+	return c.resource().UpdateCtx(ctx, id, request)
 }
 
 func (c *Client) Delete(id string) error {
-	return c.Api.Delete("/tax_rates/" + id)
+	return c.DeleteCtx(context.Background(), id)
+}
+
+func (c *Client) DeleteCtx(ctx context.Context, id string) error {
+	return c.resource().DeleteCtx(ctx, id)
 }
 
 func (c *Client) ListAll(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.TaxRates, error) {
-	endpoint := invoiced.AddFilterAndSort("/tax_rates", filter, sort)
+	return c.ListAllCtx(context.Background(), filter, sort)
+}
+
+func (c *Client) ListAllCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.TaxRates, error) {
+	return c.resource().ListAllCtx(ctx, filter, sort)
+}
+
+func (c *Client) Iterate(filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.TaxRate] {
+	return c.IterateCtx(context.Background(), filter, sort)
+}
 
-	taxRates := make(invoiced.TaxRates, 0)
+func (c *Client) IterateCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.TaxRate] {
+	return c.resource().IterateCtx(ctx, filter, sort)
+}
+
+func (c *Client) List(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.TaxRates, string, error) {
+	return c.ListCtx(context.Background(), filter, sort)
+}
+
+func (c *Client) ListCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.TaxRates, string, error) {
+	return c.resource().ListCtx(ctx, filter, sort)
+}
 
-NEXT:
-	tmpTaxRates := make(invoiced.TaxRates, 0)
+func (c *Client) ListQuery(query *invoiced.Query) (invoiced.TaxRates, string, error) {
+	return c.ListQueryCtx(context.Background(), query)
+}
 
-	endpointTmp, err := c.Api.Get(endpoint, &tmpTaxRates)
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) ListQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.TaxRates, string, error) {
+	return c.resource().ListQueryCtx(ctx, query)
+}
 
-	taxRates = append(taxRates, tmpTaxRates...)
+func (c *Client) ListAllQuery(query *invoiced.Query) (invoiced.TaxRates, error) {
+	return c.ListAllQueryCtx(context.Background(), query)
+}
 
-	if endpointTmp != "" {
-		goto NEXT
-	}
+func (c *Client) ListAllQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.TaxRates, error) {
+	return c.resource().ListAllQueryCtx(ctx, query)
+}
+
+func (c *Client) IterateQuery(query *invoiced.Query) *invoiced.Iter[*invoiced.TaxRate] {
+	return c.IterateQueryCtx(context.Background(), query)
+}
+
+func (c *Client) IterateQueryCtx(ctx context.Context, query *invoiced.Query) *invoiced.Iter[*invoiced.TaxRate] {
+	return c.resource().IterateQueryCtx(ctx, query)
+}
+
+func (c *Client) Count() (int64, error) {
+	return c.CountCtx(context.Background())
+}
 
-	return taxRates, nil
+func (c *Client) CountCtx(ctx context.Context) (int64, error) {
+	return c.resource().CountCtx(ctx)
 }
@@ -0,0 +1,66 @@
+package invoiced
+
+import "time"
+
+// The models have a time.Time accessor for each of their timestamps, named
+// after the field with a Time suffix, e.g. Invoice.DueDateTime.
+
+// Time converts a Unix timestamp of the API to a time.Time. A zero
+// timestamp, which the API uses for dates that are not set, gives the zero
+// time, so that IsZero reports it.
+func Time(timestamp int64) time.Time {
+	if timestamp == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(timestamp, 0)
+}
+
+// Unix converts a time to a Unix timestamp of the API. The zero time gives
+// 0, the timestamp of dates that are not set.
+func Unix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.Unix()
+}
+
+// Timestamp is the pointer form of Unix, to set the dates of requests, e.g.
+// DueDate: invoiced.Timestamp(due). The zero time gives nil, leaving the
+// date unset.
+func Timestamp(t time.Time) *int64 {
+	if t.IsZero() {
+		return nil
+	}
+
+	return Int64(t.Unix())
+}
+
+// StartOfDay returns midnight at the start of the day of t in loc, or in the
+// location of t when loc is nil.
+func StartOfDay(t time.Time, loc *time.Location) time.Time {
+	if loc != nil {
+		t = t.In(loc)
+	}
+
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// EndOfDay returns the last second of the day of t in loc, or in the
+// location of t when loc is nil. Timestamps of the API have a resolution of
+// one second, so a range ending at EndOfDay includes the whole day. Days
+// with a daylight saving change are handled.
+func EndOfDay(t time.Time, loc *time.Location) time.Time {
+	start := StartOfDay(t, loc)
+
+	return time.Date(start.Year(), start.Month(), start.Day()+1, 0, 0, 0, 0, start.Location()).Add(-time.Second)
+}
+
+// DayRange returns the first and last second of the days from the day of
+// from to the day of to, both included, in loc. For example the invoices of
+// March 2024 in New York are those dated within
+// DayRange(march1, march31, newYork).
+func DayRange(from, to time.Time, loc *time.Location) (time.Time, time.Time) {
+	return StartOfDay(from, loc), EndOfDay(to, loc)
+}
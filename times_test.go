@@ -0,0 +1,67 @@
+package invoiced
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestTimeConversions(t *testing.T) {
+	if !Time(0).IsZero() || Unix(time.Time{}) != 0 || Timestamp(time.Time{}) != nil {
+		t.Fatal("Expected unset dates to map to the zero time")
+	}
+
+	due := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	if *Timestamp(due) != 1714521600 || !Time(1714521600).Equal(due) {
+		t.Fatal("Unexpected conversion")
+	}
+
+	var invoice Invoice
+	if err := json.Unmarshal([]byte(`{"date":1714521600,"due_date":0}`), &invoice); err != nil {
+		t.Fatal(err)
+	}
+
+	if !invoice.DateTime().Equal(due) || !invoice.DueDateTime().IsZero() {
+		t.Fatal("Unexpected accessors", invoice.DateTime(), invoice.DueDateTime())
+	}
+
+	event := Event{Timestamp: 1714521600}
+	if !event.Time().Equal(due) {
+		t.Fatal("Unexpected event time", event.Time())
+	}
+}
+
+func TestDayRange(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 02:00 UTC on March 11 is still March 10 in New York, a day of 23 hours
+	start, end := DayRange(time.Date(2024, 3, 11, 2, 0, 0, 0, time.UTC), time.Date(2024, 3, 11, 2, 0, 0, 0, time.UTC), newYork)
+
+	if start.Format(time.RFC3339) != "2024-03-10T00:00:00-05:00" || end.Format(time.RFC3339) != "2024-03-10T23:59:59-04:00" {
+		t.Fatal("Unexpected range", start, end)
+	}
+
+	if end.Sub(start) != 23*time.Hour-time.Second {
+		t.Fatal("Unexpected length", end.Sub(start))
+	}
+
+	local := time.Date(2024, 1, 15, 18, 30, 0, 0, newYork)
+	if !StartOfDay(local, nil).Equal(time.Date(2024, 1, 15, 0, 0, 0, 0, newYork)) {
+		t.Fatal("Expected the location of the time to be used", StartOfDay(local, nil))
+	}
+}
+
+func TestFilterSetTime(t *testing.T) {
+	filter := NewFilter()
+	if err := filter.Set("date", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+
+	if filter.Get("date") != "1714521600" {
+		t.Fatal("Unexpected filter value", filter.Get("date"))
+	}
+}
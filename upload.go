@@ -0,0 +1,194 @@
+package invoiced
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// UploadFile is a file to upload from a reader.
+type UploadFile struct {
+	Reader io.Reader
+
+	// Name is the file name sent to the API.
+	Name string
+
+	// Size is the size of the file in bytes, if known. It is only used as
+	// the total reported to Progress.
+	Size int64
+
+	// ContentType is the MIME type of the file. When empty it is detected
+	// from the first bytes of the file, or else from the extension of Name.
+	ContentType string
+
+	// Progress, when set, is called as the file is sent with the number of
+	// bytes sent so far and the total size, or -1 when Size is unknown. It
+	// is called from the goroutine that writes the request body.
+	Progress func(sent, total int64)
+}
+
+// createFormFile is a convenience wrapper around CreatePart. It creates
+// a new form-data header with the provided field name and file name.
+func createFormFile(w *multipart.Writer, fieldname, filename string, fileType string) (io.Writer, error) {
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition",
+		fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+			escapeQuotes(fieldname), escapeQuotes(filename)))
+	h.Set("Content-Type", fileType)
+	return w.CreatePart(h)
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
+
+func (c *Api) Upload(endpoint string, filePath string, fileParamName string, fileParams map[string]string, fileType string, responseData interface{}) error {
+	return c.UploadCtx(context.Background(), endpoint, filePath, fileParamName, fileParams, fileType, responseData)
+}
+
+// UploadCtx is like Upload but carries ctx through to the HTTP request.
+func (c *Api) UploadCtx(ctx context.Context, endpoint string, filePath string, fileParamName string, fileParams map[string]string, fileType string, responseData interface{}) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	upload := &UploadFile{
+		Reader:      file,
+		Name:        filepath.Base(filePath),
+		Size:        info.Size(),
+		ContentType: fileType,
+	}
+
+	return c.UploadReaderCtx(ctx, endpoint, fileParamName, upload, fileParams, responseData)
+}
+
+func (c *Api) UploadReader(endpoint string, fileParamName string, file *UploadFile, fileParams map[string]string, responseData interface{}) error {
+	return c.UploadReaderCtx(context.Background(), endpoint, fileParamName, file, fileParams, responseData)
+}
+
+// UploadReaderCtx sends file as a multipart form, along with fileParams as
+// extra form fields. The form is streamed as it is built, so the file is
+// never held in memory as a whole. Since the body cannot be replayed, the
+// request is not retried.
+func (c *Api) UploadReaderCtx(ctx context.Context, endpoint string, fileParamName string, file *UploadFile, fileParams map[string]string, responseData interface{}) error {
+	reader, contentType, err := file.contentType()
+	if err != nil {
+		return err
+	}
+
+	if file.Progress != nil {
+		total := file.Size
+		if total <= 0 {
+			total = -1
+		}
+
+		reader = &progressReader{Reader: reader, total: total, progress: file.Progress}
+	}
+
+	pr, pw := io.Pipe()
+	defer pr.Close()
+
+	writer := multipart.NewWriter(pw)
+
+	go func() {
+		pw.CloseWithError(writeMultipart(writer, fileParamName, file.Name, contentType, reader, fileParams))
+	}()
+
+	resp, err := c.requestBody(ctx, "POST", endpoint, pr, nil, writer.FormDataContentType())
+	if err != nil {
+		return err
+	}
+
+	defer closeBody(resp)
+
+	apiError := checkStatusForError(resp)
+
+	if apiError != nil {
+		return apiError
+	}
+
+	if responseData == nil {
+		return nil
+	}
+
+	return pushDataIntoStruct(responseData, resp.Body)
+}
+
+func writeMultipart(writer *multipart.Writer, fileParamName, fileName, contentType string, file io.Reader, fileParams map[string]string) error {
+	part, err := createFormFile(writer, fileParamName, fileName, contentType)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(part, file); err != nil {
+		return err
+	}
+
+	for key, val := range fileParams {
+		if err := writer.WriteField(key, val); err != nil {
+			return err
+		}
+	}
+
+	return writer.Close()
+}
+
+// contentType returns the content type of the file, sniffing it when none
+// was given, along with a reader that still yields the whole file.
+func (f *UploadFile) contentType() (io.Reader, string, error) {
+	if f.ContentType != "" {
+		return f.Reader, f.ContentType, nil
+	}
+
+	head := make([]byte, 512)
+
+	n, err := io.ReadFull(f.Reader, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, "", err
+	}
+
+	head = head[:n]
+	contentType := http.DetectContentType(head)
+
+	if strings.HasPrefix(contentType, "application/octet-stream") || strings.HasPrefix(contentType, "text/plain") {
+		if byExtension := mime.TypeByExtension(filepath.Ext(f.Name)); byExtension != "" {
+			contentType = byExtension
+		}
+	}
+
+	return io.MultiReader(bytes.NewReader(head), f.Reader), contentType, nil
+}
+
+type progressReader struct {
+	io.Reader
+	sent     int64
+	total    int64
+	progress func(sent, total int64)
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if n > 0 {
+		r.sent += int64(n)
+		r.progress(r.sent, r.total)
+	}
+
+	return n, err
+}
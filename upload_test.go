@@ -0,0 +1,137 @@
+package invoiced
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type receivedUpload struct {
+	fileName    string
+	contentType string
+	content     string
+	fields      map[string]string
+}
+
+func newUploadServer(received *receivedUpload) *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reader, err := r.MultipartReader()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		received.fields = make(map[string]string)
+
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			b, _ := io.ReadAll(part)
+			if part.FileName() != "" {
+				received.fileName = part.FileName()
+				received.contentType = part.Header.Get("Content-Type")
+				received.content = string(b)
+			} else {
+				received.fields[part.FormName()] = string(b)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(&File{Id: 1234, Name: received.fileName})
+	}))
+}
+
+func TestUploadReader(t *testing.T) {
+	var received receivedUpload
+	server := newUploadServer(&received)
+	defer server.Close()
+
+	client := NewMockApi("whatever", server)
+
+	content := "%PDF-1.4\n" + strings.Repeat("x", 100000)
+
+	var lastSent, lastTotal int64
+	upload := &UploadFile{
+		Reader: strings.NewReader(content),
+		Name:   "invoice.pdf",
+		Size:   int64(len(content)),
+		Progress: func(sent, total int64) {
+			lastSent, lastTotal = sent, total
+		},
+	}
+
+	file := new(File)
+	if err := client.UploadReader("/files", "file", upload, map[string]string{"type": "attachment"}, file); err != nil {
+		t.Fatal(err)
+	}
+
+	if file.Id != 1234 {
+		t.Fatal("Unexpected response", file)
+	}
+
+	if received.fileName != "invoice.pdf" || received.contentType != "application/pdf" || received.content != content {
+		t.Fatal("Unexpected upload", received.fileName, received.contentType, len(received.content))
+	}
+
+	if received.fields["type"] != "attachment" {
+		t.Fatal("Unexpected fields", received.fields)
+	}
+
+	if lastSent != int64(len(content)) || lastTotal != int64(len(content)) {
+		t.Fatal("Unexpected progress", lastSent, lastTotal)
+	}
+}
+
+func TestUploadFromPath(t *testing.T) {
+	var received receivedUpload
+	server := newUploadServer(&received)
+	defer server.Close()
+
+	client := NewMockApi("whatever", server)
+
+	path := filepath.Join(t.TempDir(), "notes.csv")
+	if err := os.WriteFile(path, []byte("a,b\n1,2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.Upload("/files", path, "file", nil, "", new(File)); err != nil {
+		t.Fatal(err)
+	}
+
+	if received.fileName != "notes.csv" || !strings.HasPrefix(received.contentType, "text/csv") || received.content != "a,b\n1,2\n" {
+		t.Fatal("Unexpected upload", received)
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read(p []byte) (int, error) {
+	return 0, errors.New("disk on fire")
+}
+
+func TestUploadReaderSourceError(t *testing.T) {
+	var received receivedUpload
+	server := newUploadServer(&received)
+	defer server.Close()
+
+	client := NewMockApi("whatever", server)
+
+	upload := &UploadFile{Reader: io.MultiReader(strings.NewReader("partial"), failingReader{}), Name: "broken.txt", ContentType: "text/plain"}
+
+	err := client.UploadReader("/files", "file", upload, nil, new(File))
+	if err == nil || !strings.Contains(err.Error(), "disk on fire") {
+		t.Fatal("Expected the read error, got", err)
+	}
+}
@@ -14,15 +14,15 @@ type MemberRequest struct {
 }
 
 type Member struct {
-	CreatedAt            int64               `json:"created_at"`
-	UpdatedAt            int64               `json:"updated_at"`
-	EmailUpdateFrequency string              `json:"email_update_frequency"`
-	Id                   int64               `json:"id"`
-	LastSignedIn         int64               `json:"last_accessed"`
-	RestrictionMode      string              `json:"restriction_mode"`
-	Restrictions         map[string][]string `json:"restrictions"`
-	Role                 string              `json:"role"`
-	User                 *User               `json:"user"`
+	CreatedAt            int64                   `json:"created_at"`
+	UpdatedAt            int64                   `json:"updated_at"`
+	EmailUpdateFrequency string                  `json:"email_update_frequency"`
+	Id                   int64                   `json:"id"`
+	LastSignedIn         int64                   `json:"last_accessed"`
+	RestrictionMode      string                  `json:"restriction_mode"`
+	Restrictions         map[string][]string     `json:"restrictions"`
+	Role                 string                  `json:"role"`
+	User                 Expandable[User, int64] `json:"user"`
 }
 
 type Members []*Member
@@ -0,0 +1,211 @@
+package invoiced
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Validator is implemented by request types that can check their values
+// before being sent, catching mistakes that the API would otherwise reject
+// with an invalid_request error.
+type Validator interface {
+	// Validate checks the fields that are set.
+	Validate() error
+}
+
+// CreateValidator is implemented by request types that also have fields
+// required to create an object.
+type CreateValidator interface {
+	Validator
+
+	// ValidateCreate checks the fields that are set, and that the fields
+	// required to create an object are.
+	ValidateCreate() error
+}
+
+// FieldError is a problem with one field of a request. Field is the path of
+// the field using its JSON names, e.g. "items[2].quantity".
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationError lists every invalid field of a request. It matches
+// ErrInvalidRequest with errors.Is, like the errors of the API.
+type ValidationError struct {
+	Errors []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+
+	return "invoiced: invalid request: " + strings.Join(messages, "; ")
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidRequest
+}
+
+// Field returns the error of the field at the given path, if any.
+func (e *ValidationError) Field(path string) *FieldError {
+	for _, err := range e.Errors {
+		if err.Field == path {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WithValidation makes Create and Update validate the requests implementing
+// Validator before sending them. Creating uses ValidateCreate when the
+// request implements CreateValidator. Invalid requests are returned as a
+// *ValidationError without any call to the API.
+func WithValidation(enabled bool) Option {
+	return func(o *options) {
+		o.validation = enabled
+	}
+}
+
+// SetValidation is the setter form of WithValidation.
+func (c *Api) SetValidation(enabled bool) {
+	c.validation = enabled
+}
+
+func (c *Api) validate(requestData interface{}, create bool) error {
+	if !c.validation {
+		return nil
+	}
+
+	if v, ok := requestData.(CreateValidator); ok && create {
+		return v.ValidateCreate()
+	}
+
+	if v, ok := requestData.(Validator); ok {
+		return v.Validate()
+	}
+
+	return nil
+}
+
+// fieldErrors collects the errors of a request as its fields are checked.
+type fieldErrors struct {
+	errors []*FieldError
+}
+
+func (f *fieldErrors) add(field, format string, args ...interface{}) {
+	f.errors = append(f.errors, &FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (f *fieldErrors) err() error {
+	if len(f.errors) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Errors: f.errors}
+}
+
+func (f *fieldErrors) required(field string, set bool) {
+	if !set {
+		f.add(field, "is required")
+	}
+}
+
+func (f *fieldErrors) id(field string, id *int64) {
+	if id != nil && *id <= 0 {
+		f.add(field, "must be a positive id")
+	}
+}
+
+func (f *fieldErrors) nonNegative(field string, value *float64) {
+	switch {
+	case value == nil:
+	case !isFinite(*value):
+		f.add(field, "must be a finite number")
+	case *value < 0:
+		f.add(field, "must not be negative")
+	}
+}
+
+func (f *fieldErrors) nonNegativeInt(field string, value *int64) {
+	if value != nil && *value < 0 {
+		f.add(field, "must not be negative")
+	}
+}
+
+// currency checks the format of an ISO 4217 currency code, e.g. "usd".
+func (f *fieldErrors) currency(field string, code *string) {
+	if code != nil && !isLetters(*code, 3) {
+		f.add(field, "must be a 3-letter ISO 4217 currency code, got %q", *code)
+	}
+}
+
+// country checks the format of an ISO 3166-1 alpha-2 country code, e.g. "US".
+func (f *fieldErrors) country(field string, code *string) {
+	if code != nil && !isLetters(*code, 2) {
+		f.add(field, "must be a 2-letter ISO 3166-1 country code, got %q", *code)
+	}
+}
+
+func (f *fieldErrors) oneOf(field string, value *string, allowed ...string) {
+	if value == nil {
+		return
+	}
+
+	for _, a := range allowed {
+		if *value == a {
+			return
+		}
+	}
+
+	f.add(field, "must be one of %s, got %q", strings.Join(allowed, ", "), *value)
+}
+
+// notBefore checks that the timestamp of a field is not before the one of
+// another, when both are set.
+func (f *fieldErrors) notBefore(field string, value *int64, otherField string, other *int64) {
+	if value != nil && other != nil && *value != 0 && *value < *other {
+		f.add(field, "must not be before %s", otherField)
+	}
+}
+
+// isFinite excludes the NaN and infinite amounts that cannot be sent, nor
+// converted to Money.
+func isFinite(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
+
+func isLetters(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (f *fieldErrors) lineItems(field string, items []*LineItemRequest) {
+	for i, item := range items {
+		path := fmt.Sprintf("%s[%d]", field, i)
+		if item == nil {
+			f.add(path, "must not be null")
+			continue
+		}
+
+		f.nonNegative(path+".quantity", item.Quantity)
+		f.notBefore(path+".period_end", item.PeriodEnd, "period_start", item.PeriodStart)
+	}
+}
@@ -0,0 +1,157 @@
+package invoiced
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/Invoiced/invoiced-go/v2/invdmockserver"
+)
+
+func fieldsOf(t *testing.T, err error) []string {
+	t.Helper()
+
+	if err == nil {
+		return nil
+	}
+
+	var validationError *ValidationError
+	if !errors.As(err, &validationError) {
+		t.Fatal("Expected a *ValidationError, got", err)
+	}
+
+	fields := make([]string, len(validationError.Errors))
+	for i, fieldError := range validationError.Errors {
+		fields[i] = fieldError.Field
+	}
+
+	return fields
+}
+
+func expectFields(t *testing.T, err error, expected ...string) {
+	t.Helper()
+
+	fields := fieldsOf(t, err)
+	if len(fields) != len(expected) {
+		t.Fatal("Expected errors on", expected, "got", err)
+	}
+
+	for i := range expected {
+		if fields[i] != expected[i] {
+			t.Fatal("Expected errors on", expected, "got", err)
+		}
+	}
+}
+
+func TestInvoiceRequestValidate(t *testing.T) {
+	request := &InvoiceRequest{
+		Currency: String("dollars"),
+		Date:     Int64(1700000000),
+		DueDate:  Int64(1690000000),
+		Items: []*LineItemRequest{
+			{Quantity: Float64(1)},
+			{Quantity: Float64(-2)},
+			{Quantity: Float64(math.NaN())},
+			{Quantity: Float64(math.Inf(1))},
+		},
+	}
+
+	expectFields(t, request.Validate(), "currency", "due_date", "items[1].quantity", "items[2].quantity", "items[3].quantity")
+	expectFields(t, request.ValidateCreate(), "customer", "currency", "due_date", "items[1].quantity", "items[2].quantity", "items[3].quantity")
+
+	var validationError *ValidationError
+	errors.As(request.Validate(), &validationError)
+	if fieldError := validationError.Field("items[2].quantity"); fieldError == nil || fieldError.Message != "must be a finite number" {
+		t.Fatal("Unexpected error", fieldError)
+	}
+
+	valid := &InvoiceRequest{Customer: Int64(1), Currency: String("usd"), Date: Int64(1690000000), DueDate: Int64(1700000000)}
+	expectFields(t, valid.ValidateCreate())
+}
+
+func TestCustomerRequestValidate(t *testing.T) {
+	request := &CustomerRequest{Type: String("business"), Country: String("USA"), Email: String("nobody")}
+
+	err := request.ValidateCreate()
+	expectFields(t, err, "name", "country", "type", "email")
+
+	var validationError *ValidationError
+	errors.As(err, &validationError)
+	if fieldError := validationError.Field("type"); fieldError == nil || fieldError.Message != `must be one of company, person, got "business"` {
+		t.Fatal("Unexpected error", fieldError)
+	}
+
+	expectFields(t, (&CustomerRequest{Email: String("billing@example.com")}).Validate())
+}
+
+func TestPaymentRequestValidate(t *testing.T) {
+	request := &PaymentRequest{
+		Amount: Float64(100),
+		AppliedTo: []*PaymentItemRequest{
+			{Type: String("invoice"), Invoice: Int64(1), Amount: Float64(60.1)},
+			{Type: String("invoice"), Invoice: Int64(2), Amount: Float64(39.91)},
+		},
+	}
+
+	err := request.Validate()
+	expectFields(t, err, "applied_to")
+
+	if err.Error() != "invoiced: invalid request: applied_to: applies 100.01, more than the payment amount of 100.00" {
+		t.Fatal("Unexpected message", err)
+	}
+
+	request.AppliedTo[1].Amount = Float64(39.9)
+	expectFields(t, request.Validate())
+
+	expectFields(t, (&PaymentRequest{}).ValidateCreate(), "amount", "customer")
+	expectFields(t, (&PaymentRequest{Amount: Float64(100)}).ValidateCreate(), "customer")
+	expectFields(t, (&PaymentRequest{Amount: Float64(100), Customer: Int64(1234)}).ValidateCreate())
+
+	nonFinite := &PaymentRequest{
+		Amount:    Float64(math.Inf(1)),
+		AppliedTo: []*PaymentItemRequest{{Amount: Float64(math.NaN())}, {Amount: Float64(10)}},
+	}
+	expectFields(t, nonFinite.Validate(), "amount", "applied_to[0].amount")
+}
+
+func TestSubscriptionRequestValidate(t *testing.T) {
+	request := &SubscriptionRequest{
+		Quantity: Float64(-1),
+		Addons:   []*SubscriptionAddonRequest{{Quantity: Float64(1)}},
+	}
+
+	expectFields(t, request.ValidateCreate(), "customer", "plan", "quantity", "addons[0].plan")
+}
+
+func TestValidationOnCreate(t *testing.T) {
+	server, err := invdmockserver.NewScriptedServer(true, invdmockserver.ScriptedResponse{Status: 200, Body: map[string]interface{}{"id": 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	client := NewMockApi("api key", server.Server)
+
+	// validation is opt-in
+	if err := client.Create("/invoices", &InvoiceRequest{}, new(Invoice)); err != nil {
+		t.Fatal(err)
+	}
+
+	client.SetValidation(true)
+
+	err = client.Create("/invoices", &InvoiceRequest{Currency: String("us")}, new(Invoice))
+	if !errors.Is(err, ErrInvalidRequest) || !IsInvalidRequest(err) {
+		t.Fatal("Expected an invalid request error, got", err)
+	}
+	expectFields(t, err, "customer", "currency")
+
+	// updates do not require the fields needed to create
+	err = client.Update("/invoices/1", &InvoiceRequest{DueDate: Int64(1)}, new(Invoice))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(server.Requests()) != 2 {
+		t.Fatal("Expected the invalid request not to be sent, got", len(server.Requests()), "requests")
+	}
+}
@@ -1,6 +1,7 @@
 package webhookattempt
 
 import (
+	"context"
 	"github.com/Invoiced/invoiced-go/v2"
 	"strconv"
 )
@@ -9,29 +10,73 @@ type Client struct {
 	*invoiced.Api
 }
 
+func (c *Client) resource() invoiced.Resource[invoiced.WebhookAttempt, struct{}, int64] {
+	return invoiced.NewResource[invoiced.WebhookAttempt, struct{}, int64](c.Api, "webhook_attempt", "/webhook_attempts")
+}
+
 func (c *Client) ListAll(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.WebhookAttempts, error) {
-	endpoint2 := invoiced.AddFilterAndSort("/webhook_attempts", filter, sort)
+	return c.ListAllCtx(context.Background(), filter, sort)
+}
+
+func (c *Client) ListAllCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.WebhookAttempts, error) {
+	return c.resource().ListAllCtx(ctx, filter, sort)
+}
+
+func (c *Client) Iterate(filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.WebhookAttempt] {
+	return c.IterateCtx(context.Background(), filter, sort)
+}
+
+func (c *Client) IterateCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) *invoiced.Iter[*invoiced.WebhookAttempt] {
+	return c.resource().IterateCtx(ctx, filter, sort)
+}
+
+func (c *Client) List(filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.WebhookAttempts, string, error) {
+	return c.ListCtx(context.Background(), filter, sort)
+}
+
+func (c *Client) ListCtx(ctx context.Context, filter *invoiced.Filter, sort *invoiced.Sort) (invoiced.WebhookAttempts, string, error) {
+	return c.resource().ListCtx(ctx, filter, sort)
+}
+
+func (c *Client) ListQuery(query *invoiced.Query) (invoiced.WebhookAttempts, string, error) {
+	return c.ListQueryCtx(context.Background(), query)
+}
 
-	webhookAttempts := make(invoiced.WebhookAttempts, 0)
+func (c *Client) ListQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.WebhookAttempts, string, error) {
+	return c.resource().ListQueryCtx(ctx, query)
+}
 
-NEXT:
-	tmpWebhookAttempts := make(invoiced.WebhookAttempts, 0)
+func (c *Client) ListAllQuery(query *invoiced.Query) (invoiced.WebhookAttempts, error) {
+	return c.ListAllQueryCtx(context.Background(), query)
+}
 
-	endpoint, err := c.Api.Get(endpoint2, &tmpWebhookAttempts)
+func (c *Client) ListAllQueryCtx(ctx context.Context, query *invoiced.Query) (invoiced.WebhookAttempts, error) {
+	return c.resource().ListAllQueryCtx(ctx, query)
+}
 
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) IterateQuery(query *invoiced.Query) *invoiced.Iter[*invoiced.WebhookAttempt] {
+	return c.IterateQueryCtx(context.Background(), query)
+}
 
-	webhookAttempts = append(webhookAttempts, tmpWebhookAttempts...)
+func (c *Client) IterateQueryCtx(ctx context.Context, query *invoiced.Query) *invoiced.Iter[*invoiced.WebhookAttempt] {
+	return c.resource().IterateQueryCtx(ctx, query)
+}
 
-	if endpoint != "" {
-		goto NEXT
-	}
+func (c *Client) Count() (int64, error) {
+	return c.CountCtx(context.Background())
+}
 
-	return webhookAttempts, nil
+func (c *Client) CountCtx(ctx context.Context) (int64, error) {
+	return c.resource().CountCtx(ctx)
 }
 
 func (c *Client) ReAttempt(webhookId int64) error {
-	return c.Api.PostWithoutData("/webhook_attempts/"+strconv.FormatInt(webhookId, 10)+"/retries", nil)
+	return c.ReAttemptCtx(context.Background(), webhookId)
+}
+
+func (c *Client) ReAttemptCtx(ctx context.Context, webhookId int64) (err error) {
+	ctx, op := c.Api.StartOperation(ctx, "webhook_attempt.re_attempt")
+	defer func() { op.End(err) }()
+
+	return c.Api.PostWithoutDataCtx(ctx, "/webhook_attempts/"+strconv.FormatInt(webhookId, 10)+"/retries", nil)
 }